@@ -0,0 +1,104 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ErrNoClipboardImage is returned when the clipboard does not contain an image.
+var ErrNoClipboardImage = errors.New("the clipboard does not contain an image")
+
+// ImageExtensions are the file types accepted as inline images.
+var ImageExtensions = []string{".png", ".jpg", ".jpeg", ".gif", ".webp"}
+
+// IsImageFile reports whether the path has one of the ImageExtensions.
+func IsImageFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range ImageExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// SaveClipboardImage writes the image in the system clipboard as PNG into dir and
+// returns the file path. The Fyne clipboard only handles text, so the platform tools
+// are used: wl-paste or xclip on Linux, osascript on macOS and PowerShell on Windows.
+func SaveClipboardImage(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("screenshot-%s.png", time.Now().Format("20060102-150405")))
+
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf(`set f to open for access POSIX file %q with write permission
+write (the clipboard as «class PNGf») to f
+close access f`, path)
+		if out, err := exec.Command("osascript", "-e", script).CombinedOutput(); err != nil {
+			os.Remove(path)
+			// the clipboard can't be read as PNG if it holds no image
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && strings.Contains(string(out), "PNGf") {
+				return "", ErrNoClipboardImage
+			}
+			return "", fmt.Errorf("osascript: %w: %s", err, strings.TrimSpace(string(out)))
+		}
+	case "windows":
+		script := fmt.Sprintf(`Add-Type -AssemblyName System.Windows.Forms; $img = [System.Windows.Forms.Clipboard]::GetImage(); if ($img -eq $null) { exit 3 }; $img.Save('%s', [System.Drawing.Imaging.ImageFormat]::Png)`, strings.ReplaceAll(path, "'", "''"))
+		if out, err := exec.Command("powershell", "-NoProfile", "-STA", "-Command", script).CombinedOutput(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && exitErr.ExitCode() == 3 {
+				return "", ErrNoClipboardImage
+			}
+			return "", fmt.Errorf("powershell: %w: %s", err, strings.TrimSpace(string(out)))
+		}
+	default:
+		data, wlErr := clipboardToolOutput("wl-paste", "--no-newline", "--type", "image/png")
+		if len(data) == 0 {
+			var xErr error
+			data, xErr = clipboardToolOutput("xclip", "-selection", "clipboard", "-target", "image/png", "-out")
+			switch {
+			case errors.Is(wlErr, exec.ErrNotFound) && errors.Is(xErr, exec.ErrNotFound):
+				return "", fmt.Errorf("reading images from the clipboard needs wl-paste or xclip: %w", exec.ErrNotFound)
+			case wlErr != nil && !errors.Is(wlErr, exec.ErrNotFound):
+				return "", wlErr
+			case xErr != nil && !errors.Is(xErr, exec.ErrNotFound):
+				return "", xErr
+			}
+		}
+		if len(data) == 0 {
+			return "", ErrNoClipboardImage
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return "", fmt.Errorf("saving the clipboard image: %w", err)
+		}
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+		os.Remove(path)
+		return "", ErrNoClipboardImage
+	}
+	return path, nil
+}
+
+// clipboardToolOutput runs a clipboard tool. The tools exit with an error status if the
+// clipboard has nothing in the requested format, which is reported as empty output.
+func clipboardToolOutput(name string, args ...string) ([]byte, error) {
+	out, err := exec.Command(name, args...).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,44 @@
+package helper
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FuzzyScore matches pattern as a case-insensitive subsequence of text.
+// It returns false if not all pattern characters are found. Higher scores mean
+// better matches: consecutive characters, word starts and prefixes count extra.
+func FuzzyScore(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(strings.TrimSpace(pattern)))
+	if len(p) == 0 {
+		return 0, true
+	}
+	t := []rune(text)
+	lower := []rune(strings.ToLower(text))
+
+	score := 0
+	pi := 0
+	prevMatch := -2
+	for ti := 0; ti < len(lower) && pi < len(p); ti++ {
+		if lower[ti] != p[pi] {
+			continue
+		}
+		score++
+		if ti == prevMatch+1 {
+			score += 3
+		}
+		if ti == 0 {
+			score += 5
+		} else if !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) || unicode.IsUpper(t[ti]) && unicode.IsLower(t[ti-1]) {
+			score += 2
+		}
+		prevMatch = ti
+		pi++
+	}
+
+	if pi < len(p) {
+		return 0, false
+	}
+	// prefer shorter texts when the match quality is the same
+	return score*100 - len(t), true
+}
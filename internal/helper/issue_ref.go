@@ -0,0 +1,42 @@
+package helper
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	issueKeyPattern       = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[0-9]+$`)
+	issueKeyInPathPattern = regexp.MustCompile(`/(?:browse|issues)/([A-Za-z][A-Za-z0-9_]*-[0-9]+)`)
+)
+
+// ParseIssueReference extracts an issue key from a plain key ("ABC-123") or a Jira URL.
+// Both Cloud and Server links are supported, e.g. .../browse/ABC-123,
+// .../issues/ABC-123 and board links with ?selectedIssue=ABC-123.
+func ParseIssueReference(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", false
+	}
+
+	if key := strings.ToUpper(text); issueKeyPattern.MatchString(key) {
+		return key, true
+	}
+
+	u, err := url.Parse(text)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", false
+	}
+
+	for _, param := range []string{"selectedIssue", "issueKey"} {
+		if key := strings.ToUpper(u.Query().Get(param)); issueKeyPattern.MatchString(key) {
+			return key, true
+		}
+	}
+
+	if m := issueKeyInPathPattern.FindStringSubmatch(u.Path); m != nil {
+		return strings.ToUpper(m[1]), true
+	}
+	return "", false
+}
@@ -0,0 +1,36 @@
+package models
+
+import "strings"
+
+// TextToADF converts plain text into an Atlassian Document Format document
+// with one paragraph per line block.
+func TextToADF(text string) map[string]interface{} {
+	var content []interface{}
+	for _, block := range strings.Split(text, "\n\n") {
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+		var inline []interface{}
+		for i, line := range strings.Split(block, "\n") {
+			if i > 0 {
+				inline = append(inline, map[string]interface{}{"type": "hardBreak"})
+			}
+			if line != "" {
+				inline = append(inline, map[string]interface{}{"type": "text", "text": line})
+			}
+		}
+		content = append(content, map[string]interface{}{
+			"type":    "paragraph",
+			"content": inline,
+		})
+	}
+	if content == nil {
+		content = []interface{}{}
+	}
+
+	return map[string]interface{}{
+		"type":    "doc",
+		"version": 1,
+		"content": content,
+	}
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Sprint states of the Jira Software agile API.
+const (
+	SprintActive = "active"
+	SprintFuture = "future"
+	SprintClosed = "closed"
+)
+
+type JiraBoard struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Location struct {
+		ProjectKey  string `json:"projectKey"`
+		DisplayName string `json:"displayName"`
+	} `json:"location"`
+}
+
+type JiraSprint struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	State         string `json:"state"`
+	Goal          string `json:"goal"`
+	StartDate     string `json:"startDate"`
+	EndDate       string `json:"endDate"`
+	CompleteDate  string `json:"completeDate"`
+	OriginBoardID int    `json:"originBoardId"`
+}
+
+type agilePage[T any] struct {
+	StartAt    int  `json:"startAt"`
+	MaxResults int  `json:"maxResults"`
+	Total      int  `json:"total"`
+	IsLast     bool `json:"isLast"`
+	Values     []T  `json:"values"`
+}
+
+// fetchAgilePages loads all pages of an agile API list. url must already contain a query.
+func fetchAgilePages[T any](url, email, token string) ([]T, error) {
+	var all []T
+	startAt := 0
+	for {
+		var page agilePage[T]
+		if err := jiraCall("GET", fmt.Sprintf("%s&startAt=%d&maxResults=50", url, startAt), email, token, nil, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			return all, nil
+		}
+	}
+}
+
+// FetchBoards returns the Scrum and Kanban boards of a project. An empty projectKey returns all boards.
+func FetchBoards(domain, email, token, projectKey string) ([]JiraBoard, error) {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/board?projectKeyOrId=%s", domain, url.QueryEscape(projectKey))
+	return fetchAgilePages[JiraBoard](u, email, token)
+}
+
+// FetchSprints returns the sprints of a board in the given states (e.g. SprintActive, SprintFuture).
+func FetchSprints(domain, email, token string, boardID int, states ...string) ([]JiraSprint, error) {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/board/%d/sprint?state=%s", domain, boardID, url.QueryEscape(strings.Join(states, ",")))
+	return fetchAgilePages[JiraSprint](u, email, token)
+}
+
+// FetchRankedIssues returns the first issues of a sprint, or of the board backlog if
+// sprintID is 0, in rank order.
+func FetchRankedIssues(domain, email, token string, boardID, sprintID, max int) ([]JiraIssue, error) {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/board/%d/backlog", domain, boardID)
+	if sprintID != 0 {
+		u = fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/sprint/%d/issue", domain, sprintID)
+	}
+	u += fmt.Sprintf("?maxResults=%d&fields=summary,status,issuetype,priority&jql=%s", max, url.QueryEscape("ORDER BY Rank ASC"))
+
+	var result JiraSearchResult
+	if err := jiraCall("GET", u, email, token, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Issues, nil
+}
+
+// maxAgileMoveIssues is the most issues the agile move endpoints accept per call.
+const maxAgileMoveIssues = 50
+
+// MoveIssuesToSprint adds the issues to a sprint.
+func MoveIssuesToSprint(domain, email, token string, sprintID int, keys []string) error {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/sprint/%d/issue", domain, sprintID)
+	return moveIssues(u, email, token, keys)
+}
+
+// MoveIssuesToBacklog removes the issues from their sprints.
+func MoveIssuesToBacklog(domain, email, token string, keys []string) error {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/backlog/issue", domain)
+	return moveIssues(u, email, token, keys)
+}
+
+// moveIssues posts the issues to a move endpoint in batches of maxAgileMoveIssues.
+func moveIssues(u, email, token string, keys []string) error {
+	for start := 0; start < len(keys); start += maxAgileMoveIssues {
+		batch := keys[start:min(start+maxAgileMoveIssues, len(keys))]
+		if err := jiraCall("POST", u, email, token, map[string]interface{}{"issues": batch}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RankIssues ranks the issues directly before (above) the given issue.
+func RankIssues(domain, email, token string, keys []string, beforeKey string) error {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/issue/rank", domain)
+	payload := map[string]interface{}{
+		"issues":          keys,
+		"rankBeforeIssue": beforeKey,
+	}
+	return rankIssues(u, email, token, payload)
+}
+
+// RankIssuesAfter ranks the issues directly after (below) the given issue.
+func RankIssuesAfter(domain, email, token string, keys []string, afterKey string) error {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/issue/rank", domain)
+	payload := map[string]interface{}{
+		"issues":         keys,
+		"rankAfterIssue": afterKey,
+	}
+	return rankIssues(u, email, token, payload)
+}
+
+// rankIssues sends a rank request. Jira answers 207 Multi-Status if some issues could
+// not be ranked, with the result of every issue in the body.
+func rankIssues(u, email, token string, payload map[string]interface{}) error {
+	var result struct {
+		Entries []struct {
+			IssueKey string   `json:"issueKey"`
+			Status   int      `json:"status"`
+			Errors   []string `json:"errors"`
+		} `json:"entries"`
+	}
+	if err := jiraCall("PUT", u, email, token, payload, &result); err != nil {
+		return err
+	}
+	var failed []string
+	for _, e := range result.Entries {
+		if e.Status < 200 || e.Status >= 300 {
+			failed = append(failed, fmt.Sprintf("%s (%d): %s", e.IssueKey, e.Status, strings.Join(e.Errors, ", ")))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("ranking failed for %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRankIssuesMultiStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{name: "all ranked", status: http.StatusNoContent},
+		{
+			name:   "all entries fine",
+			status: http.StatusMultiStatus,
+			body:   `{"entries":[{"issueKey":"ABC-1","status":200}]}`,
+		},
+		{
+			name:    "one failed",
+			status:  http.StatusMultiStatus,
+			body:    `{"entries":[{"issueKey":"ABC-1","status":200},{"issueKey":"ABC-2","status":404,"errors":["Issue does not exist"]}]}`,
+			wantErr: "ABC-2 (404): Issue does not exist",
+		},
+		{name: "request failed", status: http.StatusBadRequest, body: `{"errorMessages":["bad"]}`, wantErr: "bad"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			err := rankIssues(srv.URL, "me@example.com", "token", map[string]interface{}{"issues": []string{"ABC-1", "ABC-2"}})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
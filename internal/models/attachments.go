@@ -0,0 +1,209 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type JiraAttachment struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	MimeType string `json:"mimeType"`
+	Content  string `json:"content"`
+}
+
+// mediaFileID matches the Media Services file ID in the attachment download redirect.
+var mediaFileID = regexp.MustCompile(`/file/([0-9a-fA-F-]{36})/`)
+
+// UploadAttachment attaches a local file to the issue.
+func UploadAttachment(domain, email, token, issueKey, path string) (JiraAttachment, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return JiraAttachment{}, err
+	}
+	defer file.Close()
+	return uploadAttachmentData(domain, email, token, issueKey, filepath.Base(path), file)
+}
+
+// uploadAttachmentData attaches the content of r as file name to the issue.
+func uploadAttachmentData(domain, email, token, issueKey, name string, r io.Reader) (JiraAttachment, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", name)
+	if err != nil {
+		return JiraAttachment{}, err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return JiraAttachment{}, err
+	}
+	if err := writer.Close(); err != nil {
+		return JiraAttachment{}, err
+	}
+
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s/attachments", domain, issueKey)
+	req, err := newJiraRequest("POST", url, email, token, nil)
+	if err != nil {
+		return JiraAttachment{}, err
+	}
+	req.Body = io.NopCloser(&body)
+	req.ContentLength = int64(body.Len())
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	// required by Jira for multipart uploads
+	req.Header.Set("X-Atlassian-Token", "no-check")
+
+	var attachments []JiraAttachment
+	if err := doJiraRequest(req, &attachments); err != nil {
+		return JiraAttachment{}, err
+	}
+	if len(attachments) == 0 {
+		return JiraAttachment{}, fmt.Errorf("jira did not return the uploaded attachment %s", name)
+	}
+	return attachments[0], nil
+}
+
+// CopyAttachment downloads an attachment and attaches it to another issue.
+func CopyAttachment(domain, email, token string, attachment JiraAttachment, issueKey string) error {
+	req, err := newJiraRequest("GET", attachment.Content, email, token, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "*/*")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download attachment %s (%d)", attachment.Filename, res.StatusCode)
+	}
+	_, err = uploadAttachmentData(domain, email, token, issueKey, attachment.Filename, res.Body)
+	return err
+}
+
+// AttachmentMediaID returns the Media Services ID of an attachment, which ADF media nodes
+// reference. Jira only exposes it in the redirect of the attachment download.
+func AttachmentMediaID(domain, email, token, attachmentID string) (string, error) {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/attachment/content/%s", domain, attachmentID)
+	req, err := newJiraRequest("GET", url, email, token, nil)
+	if err != nil {
+		return "", err
+	}
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	match := mediaFileID.FindStringSubmatch(res.Header.Get("Location"))
+	if match == nil {
+		return "", fmt.Errorf("no media ID for attachment %s (%d)", attachmentID, res.StatusCode)
+	}
+	return match[1], nil
+}
+
+// UploadInlineImages uploads the files and returns their media IDs by file name for
+// TextWithMediaToADF. Files whose media ID can't be resolved stay plain attachments.
+func UploadInlineImages(domain, email, token, issueKey string, paths []string) (map[string]string, error) {
+	media := map[string]string{}
+	for _, path := range paths {
+		attachment, err := UploadAttachment(domain, email, token, issueKey, path)
+		if err != nil {
+			return media, err
+		}
+		id, err := AttachmentMediaID(domain, email, token, attachment.ID)
+		if err != nil {
+			fmt.Println("Error resolving media ID:", err)
+			continue
+		}
+		media[filepath.Base(path)] = id
+	}
+	return media, nil
+}
+
+// AttachInlineImages uploads the images to an existing issue and replaces its description
+// with text, where the placeholders of the images become inline media.
+func AttachInlineImages(domain, email, token, issueKey, text string, paths []string) error {
+	media, err := UploadInlineImages(domain, email, token, issueKey, paths)
+	if err != nil {
+		return err
+	}
+	return UpdateIssueDescription(domain, email, token, issueKey, TextWithMediaToADF(text, media))
+}
+
+// AttachmentPlaceholder is the line inserted into a text to mark the position of an image.
+func AttachmentPlaceholder(name string) string {
+	return "!" + name + "!"
+}
+
+// TextWithMediaToADF converts text like TextToADF, but lines consisting of an
+// AttachmentPlaceholder of an uploaded image become inline media nodes.
+func TextWithMediaToADF(text string, media map[string]string) map[string]interface{} {
+	var content []interface{}
+	var block []string
+	flush := func() {
+		if len(block) > 0 {
+			content = append(content, TextToADF(strings.Join(block, "\n"))["content"].([]interface{})...)
+			block = nil
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		name := strings.TrimSuffix(strings.TrimPrefix(trimmed, "!"), "!")
+		if id, ok := media[name]; ok && trimmed == AttachmentPlaceholder(name) {
+			flush()
+			content = append(content, map[string]interface{}{
+				"type":  "mediaSingle",
+				"attrs": map[string]interface{}{"layout": "center"},
+				"content": []interface{}{
+					map[string]interface{}{
+						"type": "media",
+						"attrs": map[string]interface{}{
+							"type":       "file",
+							"id":         id,
+							"collection": "",
+						},
+					},
+				},
+			})
+			continue
+		}
+		block = append(block, line)
+	}
+	flush()
+
+	if content == nil {
+		content = []interface{}{}
+	}
+	return map[string]interface{}{
+		"type":    "doc",
+		"version": 1,
+		"content": content,
+	}
+}
+
+// UpdateIssueDescription replaces the description of an issue with an ADF document.
+func UpdateIssueDescription(domain, email, token, issueKey string, adf map[string]interface{}) error {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s", domain, issueKey)
+	payload := map[string]interface{}{
+		"fields": map[string]interface{}{"description": adf},
+	}
+	return jiraCall("PUT", url, email, token, payload, nil)
+}
+
+// AddCommentADF adds a comment given as ADF document, e.g. with inline images.
+func AddCommentADF(domain, email, token, issueKey string, adf map[string]interface{}) error {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s/comment", domain, issueKey)
+	return jiraCall("POST", url, email, token, map[string]interface{}{"body": adf}, nil)
+}
@@ -0,0 +1,264 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// MaxBoardIssues limits the issues loaded for a board view or sprint.
+const MaxBoardIssues = 500
+
+// Board column constraint types; with issueCountExclSubs sub-tasks don't count for WIP limits.
+const (
+	BoardConstraintNone        = "none"
+	BoardConstraintIssueCount  = "issueCount"
+	BoardConstraintExclSubtask = "issueCountExclSubs"
+)
+
+// BoardColumn is a column of a board with the statuses mapped to it and its WIP limits
+// (0 = no limit).
+type BoardColumn struct {
+	Name      string
+	StatusIDs []string
+	Min       int
+	Max       int
+}
+
+// HasStatus reports whether the status is mapped to the column.
+func (c BoardColumn) HasStatus(statusID string) bool {
+	for _, id := range c.StatusIDs {
+		if id == statusID {
+			return true
+		}
+	}
+	return false
+}
+
+// BoardConfiguration is the column configuration of a board.
+type BoardConfiguration struct {
+	ID             int
+	Name           string
+	Columns        []BoardColumn
+	ConstraintType string
+	// EstimationField is the field holding estimates (usually story points), "" if none.
+	EstimationField string
+	EstimationName  string
+}
+
+// FetchBoardConfiguration loads the columns and their status mapping of a board.
+func FetchBoardConfiguration(domain, email, token string, boardID int) (BoardConfiguration, error) {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/board/%d/configuration", domain, boardID)
+	var raw struct {
+		ID           int    `json:"id"`
+		Name         string `json:"name"`
+		ColumnConfig struct {
+			Columns []struct {
+				Name     string `json:"name"`
+				Statuses []struct {
+					ID string `json:"id"`
+				} `json:"statuses"`
+				Min int `json:"min"`
+				Max int `json:"max"`
+			} `json:"columns"`
+			ConstraintType string `json:"constraintType"`
+		} `json:"columnConfig"`
+		Estimation struct {
+			Type  string `json:"type"`
+			Field struct {
+				FieldID     string `json:"fieldId"`
+				DisplayName string `json:"displayName"`
+			} `json:"field"`
+		} `json:"estimation"`
+	}
+	if err := jiraCall("GET", u, email, token, nil, &raw); err != nil {
+		return BoardConfiguration{}, err
+	}
+
+	config := BoardConfiguration{ID: raw.ID, Name: raw.Name, ConstraintType: raw.ColumnConfig.ConstraintType}
+	if raw.Estimation.Type == "field" {
+		config.EstimationField = raw.Estimation.Field.FieldID
+		config.EstimationName = raw.Estimation.Field.DisplayName
+	}
+	for _, c := range raw.ColumnConfig.Columns {
+		column := BoardColumn{Name: c.Name, Min: c.Min, Max: c.Max}
+		for _, s := range c.Statuses {
+			column.StatusIDs = append(column.StatusIDs, s.ID)
+		}
+		// columns without statuses (e.g. an unmapped Backlog column) can't hold issues
+		if len(column.StatusIDs) > 0 {
+			config.Columns = append(config.Columns, column)
+		}
+	}
+	return config, nil
+}
+
+// BoardIssue is an issue as shown on a board card.
+type BoardIssue struct {
+	ID        string
+	Key       string
+	Summary   string
+	Status    JiraStatus
+	IssueType JiraIssueType
+	Priority  JiraPriority
+	// Assignee is empty for unassigned issues.
+	Assignee JiraUser
+	EpicKey  string
+	EpicName string
+	// Estimate is the value of the board's estimation field, 0 if not estimated.
+	Estimate float64
+}
+
+// boardIssueFields is the fields part of issues returned by the agile API.
+type boardIssueFields struct {
+	Summary   string        `json:"summary"`
+	Status    JiraStatus    `json:"status"`
+	IssueType JiraIssueType `json:"issuetype"`
+	Priority  JiraPriority  `json:"priority"`
+	Assignee  *JiraUser     `json:"assignee"`
+	Parent    *struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Summary   string `json:"summary"`
+			IssueType struct {
+				HierarchyLevel int `json:"hierarchyLevel"`
+			} `json:"issuetype"`
+		} `json:"fields"`
+	} `json:"parent"`
+	// Epic is only set by the agile API for company-managed projects.
+	Epic *struct {
+		Key     string `json:"key"`
+		Name    string `json:"name"`
+		Summary string `json:"summary"`
+	} `json:"epic"`
+}
+
+func (f boardIssueFields) toBoardIssue(id, key string) BoardIssue {
+	issue := BoardIssue{
+		ID:        id,
+		Key:       key,
+		Summary:   f.Summary,
+		Status:    f.Status,
+		IssueType: f.IssueType,
+		Priority:  f.Priority,
+	}
+	if f.Assignee != nil {
+		issue.Assignee = *f.Assignee
+	}
+	switch {
+	case f.Epic != nil:
+		issue.EpicKey = f.Epic.Key
+		issue.EpicName = f.Epic.Name
+		if issue.EpicName == "" {
+			issue.EpicName = f.Epic.Summary
+		}
+	case f.Parent != nil && f.Parent.Fields.IssueType.HierarchyLevel == 1:
+		issue.EpicKey = f.Parent.Key
+		issue.EpicName = f.Parent.Fields.Summary
+	}
+	return issue
+}
+
+// FetchBoardIssues returns the issues shown on a board: for Scrum boards those of the
+// active sprints, for Kanban boards everything not done or changed within two weeks.
+func FetchBoardIssues(domain, email, token string, board JiraBoard) ([]BoardIssue, error) {
+	jql := `statusCategory != Done OR updated >= -14d`
+	if board.Type == "scrum" {
+		jql = `sprint in openSprints()`
+	}
+	base := fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/board/%d/issue?fields=summary,status,issuetype,priority,assignee,parent,epic&jql=%s",
+		domain, board.ID, url.QueryEscape(jql+" ORDER BY Rank ASC"))
+	return fetchBoardIssuePages(base, email, token, "")
+}
+
+// FetchSprintIssues returns the issues of a sprint, or of the board backlog if sprintID
+// is 0, in rank order. estimateField (see BoardConfiguration) fills BoardIssue.Estimate.
+func FetchSprintIssues(domain, email, token string, boardID, sprintID int, estimateField string) ([]BoardIssue, error) {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/board/%d/backlog", domain, boardID)
+	if sprintID != 0 {
+		u = fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/board/%d/sprint/%d/issue", domain, boardID, sprintID)
+	}
+	fields := "summary,status,issuetype,priority,assignee,parent,epic"
+	if estimateField != "" {
+		fields += "," + estimateField
+	}
+	u += fmt.Sprintf("?fields=%s&jql=%s", fields, url.QueryEscape("ORDER BY Rank ASC"))
+	return fetchBoardIssuePages(u, email, token, estimateField)
+}
+
+// fetchBoardIssuePages loads the issues of an agile API issue list, up to MaxBoardIssues.
+func fetchBoardIssuePages(base, email, token, estimateField string) ([]BoardIssue, error) {
+	var issues []BoardIssue
+	for len(issues) < MaxBoardIssues {
+		var page struct {
+			Total  int `json:"total"`
+			Issues []struct {
+				ID     string          `json:"id"`
+				Key    string          `json:"key"`
+				Fields json.RawMessage `json:"fields"`
+			} `json:"issues"`
+		}
+		if err := jiraCall("GET", fmt.Sprintf("%s&startAt=%d&maxResults=100", base, len(issues)), email, token, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, iss := range page.Issues {
+			var fields boardIssueFields
+			if err := json.Unmarshal(iss.Fields, &fields); err != nil {
+				return nil, err
+			}
+			issue := fields.toBoardIssue(iss.ID, iss.Key)
+			if estimateField != "" {
+				var raw map[string]json.RawMessage
+				var estimate float64
+				if json.Unmarshal(iss.Fields, &raw) == nil && json.Unmarshal(raw[estimateField], &estimate) == nil {
+					issue.Estimate = estimate
+				}
+			}
+			issues = append(issues, issue)
+		}
+		if len(page.Issues) == 0 || len(issues) >= page.Total {
+			break
+		}
+	}
+	return issues, nil
+}
+
+// JiraTransitionDetails is a transition with its target status and the fields of its screen.
+type JiraTransitionDetails struct {
+	ID     string               `json:"id"`
+	Name   string               `json:"name"`
+	To     JiraStatus           `json:"to"`
+	Fields map[string]JiraField `json:"fields"`
+}
+
+// FetchTransitionDetails returns the available transitions of an issue including screen fields.
+func FetchTransitionDetails(domain, email, token, issueKey string) ([]JiraTransitionDetails, error) {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s/transitions?expand=transitions.fields", domain, issueKey)
+	var out struct {
+		Transitions []JiraTransitionDetails `json:"transitions"`
+	}
+	if err := jiraCall("GET", u, email, token, nil, &out); err != nil {
+		return nil, err
+	}
+	for i := range out.Transitions {
+		for id, f := range out.Transitions[i].Fields {
+			if f.Key == "" && f.FieldID == "" {
+				f.Key = id
+				out.Transitions[i].Fields[id] = f
+			}
+		}
+	}
+	return out.Transitions, nil
+}
+
+// TransitionIssueWithFields performs a transition and sets the given screen fields.
+func TransitionIssueWithFields(domain, email, token, issueKey, transitionID string, fields map[string]interface{}) error {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s/transitions", domain, issueKey)
+	payload := map[string]interface{}{
+		"transition": map[string]string{"id": transitionID},
+	}
+	if len(fields) > 0 {
+		payload["fields"] = fields
+	}
+	return jiraCall("POST", u, email, token, payload, nil)
+}
@@ -0,0 +1,50 @@
+package models
+
+import "sync"
+
+// BulkConcurrency limits parallel Jira requests so bulk actions stay below the rate limits.
+const BulkConcurrency = 4
+
+// BulkResult is the outcome of a bulk operation for a single issue.
+type BulkResult struct {
+	Issue JiraIssue
+	Err   error
+}
+
+// RunBulk applies op to every issue with at most BulkConcurrency requests in flight.
+// progress is called once per finished issue (from worker goroutines); the full
+// result list is returned in input order once all issues are done.
+func RunBulk(issues []JiraIssue, op func(JiraIssue) error, progress func(BulkResult)) []BulkResult {
+	results := make([]BulkResult, len(issues))
+	sem := make(chan struct{}, BulkConcurrency)
+	var wg sync.WaitGroup
+
+	for i, iss := range issues {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, iss JiraIssue) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res := BulkResult{Issue: iss, Err: op(iss)}
+			results[i] = res
+			if progress != nil {
+				progress(res)
+			}
+		}(i, iss)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// FailedIssues returns the issues of all failed results, e.g. to retry them.
+func FailedIssues(results []BulkResult) []JiraIssue {
+	var failed []JiraIssue
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r.Issue)
+		}
+	}
+	return failed
+}
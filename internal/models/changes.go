@@ -0,0 +1,161 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of changes detected by FetchChangesSince.
+const (
+	ChangeAssigned = "assigned"
+	ChangeComment  = "comment"
+	ChangeStatus   = "status"
+)
+
+// jiraTimeLayout is the timestamp format used by the Jira REST API.
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// ChangeEvent is a single change on one of the user's issues.
+type ChangeEvent struct {
+	Kind    string
+	Key     string
+	Summary string
+	Author  string
+	Detail  string
+	Created time.Time
+}
+
+type jiraChangeSearchResult struct {
+	Issues []struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Summary string `json:"summary"`
+			Comment struct {
+				Comments []struct {
+					Author struct {
+						AccountID   string `json:"accountId"`
+						DisplayName string `json:"displayName"`
+					} `json:"author"`
+					Created string `json:"created"`
+				} `json:"comments"`
+			} `json:"comment"`
+		} `json:"fields"`
+		Changelog struct {
+			Histories []struct {
+				Author struct {
+					AccountID   string `json:"accountId"`
+					DisplayName string `json:"displayName"`
+				} `json:"author"`
+				Created string `json:"created"`
+				Items   []struct {
+					Field      string `json:"field"`
+					To         string `json:"to"`
+					FromString string `json:"fromString"`
+					ToString   string `json:"toString"`
+				} `json:"items"`
+			} `json:"histories"`
+		} `json:"changelog"`
+	} `json:"issues"`
+}
+
+// FetchMyself returns the user the API token belongs to.
+func FetchMyself(domain, email, token string) (JiraUser, error) {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/myself", domain)
+	var out JiraUser
+	if err := jiraCall("GET", url, email, token, nil, &out); err != nil {
+		return JiraUser{}, err
+	}
+	return out, nil
+}
+
+// FetchChangesSince looks for changes on issues assigned to the user since the given time:
+// newly assigned issues, status changes and comments written by someone else.
+func FetchChangesSince(domain, email, token, accountID string, since time.Time) ([]ChangeEvent, error) {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/search/jql", domain)
+
+	// Absolute JQL dates are read in the time zone of the user's Jira profile, a relative
+	// offset is not. JQL only knows minutes, so we query a little earlier and filter
+	// exactly below.
+	minutes := int(math.Ceil(time.Since(since).Minutes())) + 1
+	jql := fmt.Sprintf(`assignee = currentUser() AND updated >= -%dm ORDER BY updated DESC`, minutes)
+	body := map[string]interface{}{
+		"jql":        jql,
+		"fields":     []string{"summary", "comment"},
+		"expand":     "changelog",
+		"maxResults": 50,
+	}
+
+	var data jiraChangeSearchResult
+	if err := jiraCall("POST", url, email, token, body, &data); err != nil {
+		return nil, err
+	}
+
+	var events []ChangeEvent
+	for _, iss := range data.Issues {
+		for _, h := range iss.Changelog.Histories {
+			created, err := time.Parse(jiraTimeLayout, h.Created)
+			if err != nil || !created.After(since) {
+				continue
+			}
+			for _, item := range h.Items {
+				switch item.Field {
+				case "assignee":
+					if item.To == accountID && h.Author.AccountID != accountID {
+						events = append(events, ChangeEvent{ChangeAssigned, iss.Key, iss.Fields.Summary, h.Author.DisplayName, "", created})
+					}
+				case "status":
+					if h.Author.AccountID != accountID {
+						detail := fmt.Sprintf("%s → %s", item.FromString, item.ToString)
+						events = append(events, ChangeEvent{ChangeStatus, iss.Key, iss.Fields.Summary, h.Author.DisplayName, detail, created})
+					}
+				}
+			}
+		}
+
+		for _, c := range iss.Fields.Comment.Comments {
+			created, err := time.Parse(jiraTimeLayout, c.Created)
+			if err != nil || !created.After(since) || c.Author.AccountID == accountID {
+				continue
+			}
+			events = append(events, ChangeEvent{ChangeComment, iss.Key, iss.Fields.Summary, c.Author.DisplayName, "", created})
+		}
+	}
+	return events, nil
+}
+
+// InQuietHours reports whether now lies between start and end ("HH:MM").
+// Ranges may wrap around midnight, e.g. 22:00 to 07:00.
+func InQuietHours(now time.Time, start, end string) bool {
+	s, okStart := parseClock(start)
+	e, okEnd := parseClock(end)
+	if !okStart || !okEnd || s == e {
+		return false
+	}
+	minutes := now.Hour()*60 + now.Minute()
+	if s < e {
+		return minutes >= s && minutes < e
+	}
+	return minutes >= s || minutes < e
+}
+
+func parseClock(value string) (int, bool) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) != 2 {
+		return 0, false
+	}
+	h, errH := strconv.Atoi(parts[0])
+	m, errM := strconv.Atoi(parts[1])
+	if errH != nil || errM != nil || h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, false
+	}
+	return h*60 + m, true
+}
+
+// ValidClock reports whether value is a valid "HH:MM" time of day.
+func ValidClock(value string) bool {
+	_, ok := parseClock(value)
+	return ok
+}
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"fyne.io/fyne/v2"
+)
+
+// CloneLinkType is the Jira link type between a clone and its original.
+const CloneLinkType = "Cloners"
+
+// CloneOptions selects what is copied from the original issue.
+type CloneOptions struct {
+	Description  bool `json:"description"`
+	Labels       bool `json:"labels"`
+	Components   bool `json:"components"`
+	Attachments  bool `json:"attachments"`
+	Links        bool `json:"links"`
+	Subtasks     bool `json:"subtasks"`
+	LinkOriginal bool `json:"linkOriginal"`
+}
+
+// DefaultCloneOptions copies everything except attachments and sub-tasks.
+var DefaultCloneOptions = CloneOptions{Description: true, Labels: true, Components: true, Links: true, LinkOriginal: true}
+
+// LoadCloneOptions returns the options used for the last clone.
+func LoadCloneOptions(prefs fyne.Preferences) CloneOptions {
+	options := DefaultCloneOptions
+	if raw := prefs.String("clone_options"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &options); err != nil {
+			return DefaultCloneOptions
+		}
+	}
+	return options
+}
+
+// SaveCloneOptions remembers the options for the next clone.
+func SaveCloneOptions(prefs fyne.Preferences, options CloneOptions) {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return
+	}
+	prefs.SetString("clone_options", string(data))
+}
+
+type JiraIssueLink struct {
+	Type struct {
+		Name string `json:"name"`
+	} `json:"type"`
+	InwardIssue  *JiraIssueRef `json:"inwardIssue"`
+	OutwardIssue *JiraIssueRef `json:"outwardIssue"`
+}
+
+type JiraIssueRef struct {
+	Key string `json:"key"`
+}
+
+// CloneSource holds the fields of an issue that can be cloned.
+type CloneSource struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string          `json:"summary"`
+		Description json.RawMessage `json:"description"`
+		IssueType   JiraIssueType   `json:"issuetype"`
+		Labels      []string        `json:"labels"`
+		Components  []struct {
+			Name string `json:"name"`
+		} `json:"components"`
+		Attachments []JiraAttachment `json:"attachment"`
+		IssueLinks  []JiraIssueLink  `json:"issuelinks"`
+		Subtasks    []JiraIssueRef   `json:"subtasks"`
+	} `json:"fields"`
+}
+
+// FetchCloneSource loads the issue with all fields that can be cloned.
+func FetchCloneSource(domain, email, token, key string) (CloneSource, error) {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s?fields=summary,description,issuetype,labels,components,attachment,issuelinks,subtasks", domain, url.PathEscape(key))
+	var out CloneSource
+	if err := jiraCall("GET", u, email, token, nil, &out); err != nil {
+		return CloneSource{}, err
+	}
+	return out, nil
+}
+
+// FetchProjectComponents returns the component names of a project.
+func FetchProjectComponents(domain, email, token, projectKey string) ([]string, error) {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/project/%s/components", domain, url.PathEscape(projectKey))
+	var components []struct {
+		Name string `json:"name"`
+	}
+	if err := jiraCall("GET", u, email, token, nil, &components); err != nil {
+		return nil, err
+	}
+	names := make([]string, len(components))
+	for i, c := range components {
+		names[i] = c.Name
+	}
+	return names, nil
+}
+
+// CreateIssueLink links two issues. Jira names the issues from the link type's point of
+// view: for "Blocks", inwardKey blocks outwardKey; for CloneLinkType, inwardKey clones outwardKey.
+func CreateIssueLink(domain, email, token, linkType, inwardKey, outwardKey string) error {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issueLink", domain)
+	payload := map[string]interface{}{
+		"type":         map[string]string{"name": linkType},
+		"inwardIssue":  map[string]string{"key": inwardKey},
+		"outwardIssue": map[string]string{"key": outwardKey},
+	}
+	return jiraCall("POST", u, email, token, payload, nil)
+}
+
+// CopyCloneExtras copies everything the create form does not cover from the original to
+// the clone: components (by name, if the target project has them), attachments, links,
+// sub-tasks and the link to the original. All steps are tried, the errors are joined.
+func CopyCloneExtras(domain, email, token string, source CloneSource, options CloneOptions, cloneKey string) error {
+	var errs []error
+	projectKey := ProjectKeyOf(cloneKey)
+
+	if options.Components && len(source.Fields.Components) > 0 {
+		available, err := FetchProjectComponents(domain, email, token, projectKey)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		var components []map[string]string
+		for _, c := range source.Fields.Components {
+			for _, name := range available {
+				if name == c.Name {
+					components = append(components, map[string]string{"name": name})
+				}
+			}
+		}
+		if len(components) > 0 {
+			u := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s", domain, cloneKey)
+			payload := map[string]interface{}{"fields": map[string]interface{}{"components": components}}
+			if err := jiraCall("PUT", u, email, token, payload, nil); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if options.Attachments {
+		for _, a := range source.Fields.Attachments {
+			if err := CopyAttachment(domain, email, token, a, cloneKey); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if options.Links {
+		for _, link := range source.Fields.IssueLinks {
+			var err error
+			switch {
+			case link.OutwardIssue != nil:
+				err = CreateIssueLink(domain, email, token, link.Type.Name, cloneKey, link.OutwardIssue.Key)
+			case link.InwardIssue != nil:
+				err = CreateIssueLink(domain, email, token, link.Type.Name, link.InwardIssue.Key, cloneKey)
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if options.Subtasks {
+		for _, ref := range source.Fields.Subtasks {
+			if err := cloneSubtask(domain, email, token, ref.Key, projectKey, cloneKey, options); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", ref.Key, err))
+			}
+		}
+	}
+
+	if options.LinkOriginal {
+		if err := CreateIssueLink(domain, email, token, CloneLinkType, cloneKey, source.Key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// cloneSubtask creates a copy of the sub-task below the cloned parent.
+func cloneSubtask(domain, email, token, key, projectKey, parentKey string, options CloneOptions) error {
+	subtask, err := FetchCloneSource(domain, email, token, key)
+	if err != nil {
+		return err
+	}
+	fields := map[string]interface{}{"parent": map[string]string{"key": parentKey}}
+	if options.Description && len(subtask.Fields.Description) > 0 && string(subtask.Fields.Description) != "null" {
+		fields["description"] = subtask.Fields.Description
+	}
+	var labels []string
+	if options.Labels {
+		labels = subtask.Fields.Labels
+	}
+	created, err := CreateJiraIssue(domain, email, token, projectKey, subtask.Fields.IssueType.Name, subtask.Fields.Summary, "", labels, fields)
+	if err != nil {
+		return err
+	}
+	// sub-tasks of sub-tasks don't exist, the rest is copied the same way
+	options.Subtasks = false
+	return CopyCloneExtras(domain, email, token, subtask, options, created.Key)
+}
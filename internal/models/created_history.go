@@ -0,0 +1,48 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+const maxCreatedHistory = 200
+
+// CreatedHistoryEntry is an issue that was created with Jirion.
+type CreatedHistoryEntry struct {
+	Key     string    `json:"key"`
+	Summary string    `json:"summary"`
+	Created time.Time `json:"created"`
+}
+
+// LoadCreatedHistory returns the issues created with Jirion, newest first.
+func LoadCreatedHistory(prefs fyne.Preferences) []CreatedHistoryEntry {
+	var history []CreatedHistoryEntry
+	if raw := prefs.String("created_history"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &history); err != nil {
+			fmt.Println("Error reading created issues:", err)
+			return nil
+		}
+	}
+	return history
+}
+
+// AddCreatedHistory records a created issue at the top of the history.
+func AddCreatedHistory(prefs fyne.Preferences, key, summary string) {
+	history := append([]CreatedHistoryEntry{{Key: key, Summary: summary, Created: time.Now()}}, LoadCreatedHistory(prefs)...)
+	if len(history) > maxCreatedHistory {
+		history = history[:maxCreatedHistory]
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		return
+	}
+	prefs.SetString("created_history", string(data))
+}
+
+// IssueBrowseURL returns the Jira web link of an issue.
+func IssueBrowseURL(domain, key string) string {
+	return fmt.Sprintf("https://%s.atlassian.net/browse/%s", domain, key)
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type JiraFieldSchema struct {
+	Type     string `json:"type"`
+	Items    string `json:"items"`
+	System   string `json:"system"`
+	Custom   string `json:"custom"`
+	CustomID int    `json:"customId"`
+}
+
+type JiraFieldValue struct {
+	ID       string           `json:"id"`
+	Name     string           `json:"name"`
+	Value    string           `json:"value"`
+	Children []JiraFieldValue `json:"children"`
+}
+
+// Label returns the display text of an allowed value (options use Value, everything else Name).
+func (v JiraFieldValue) Label() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return v.Value
+}
+
+// JiraField describes a field on the create screen of a project and issue type.
+type JiraField struct {
+	FieldID         string           `json:"fieldId"`
+	Key             string           `json:"key"`
+	Name            string           `json:"name"`
+	Required        bool             `json:"required"`
+	Schema          JiraFieldSchema  `json:"schema"`
+	AllowedValues   []JiraFieldValue `json:"allowedValues"`
+	HasDefaultValue bool             `json:"hasDefaultValue"`
+	DefaultValue    json.RawMessage  `json:"defaultValue"`
+	AutoCompleteURL string           `json:"autoCompleteUrl"`
+}
+
+// ID returns the field ID used in the create payload.
+func (f JiraField) ID() string {
+	if f.FieldID != "" {
+		return f.FieldID
+	}
+	return f.Key
+}
+
+// DefaultIDs returns the IDs of the default value(s), if the field has any.
+func (f JiraField) DefaultIDs() []string {
+	if !f.HasDefaultValue || len(f.DefaultValue) == 0 {
+		return nil
+	}
+	var single JiraFieldValue
+	if err := json.Unmarshal(f.DefaultValue, &single); err == nil && single.ID != "" {
+		return []string{single.ID}
+	}
+	var multi []JiraFieldValue
+	if err := json.Unmarshal(f.DefaultValue, &multi); err == nil {
+		var ids []string
+		for _, v := range multi {
+			ids = append(ids, v.ID)
+		}
+		return ids
+	}
+	return nil
+}
+
+type jiraCreateMetaFieldsResponse struct {
+	StartAt    int         `json:"startAt"`
+	MaxResults int         `json:"maxResults"`
+	Total      int         `json:"total"`
+	Fields     []JiraField `json:"fields"`
+	Values     []JiraField `json:"values"`
+}
+
+// FetchCreateMetaFields loads all fields of the create screen for a project and issue type.
+func FetchCreateMetaFields(domain, email, token, projectKey, issueTypeID string) ([]JiraField, error) {
+	var fields []JiraField
+	startAt := 0
+	for {
+		url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/createmeta/%s/issuetypes/%s?startAt=%d&maxResults=50", domain, projectKey, issueTypeID, startAt)
+		var page jiraCreateMetaFieldsResponse
+		if err := jiraCall("GET", url, email, token, nil, &page); err != nil {
+			return nil, err
+		}
+
+		pageFields := page.Fields
+		if len(pageFields) == 0 {
+			pageFields = page.Values
+		}
+		fields = append(fields, pageFields...)
+
+		startAt += len(pageFields)
+		if len(pageFields) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+	return fields, nil
+}
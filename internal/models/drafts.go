@@ -0,0 +1,159 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// Draft is a locally saved, not yet created issue.
+type Draft struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Project     string    `json:"project"`
+	IssueType   string    `json:"issueType"`
+	Labels      []string  `json:"labels,omitempty"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	ParentKey   string    `json:"parentKey,omitempty"`
+	Attachments []string  `json:"attachments,omitempty"`
+	Updated     time.Time `json:"updated"`
+}
+
+// IsEmpty reports whether the draft has no content worth keeping.
+func (d Draft) IsEmpty() bool {
+	return strings.TrimSpace(d.Title) == "" && strings.TrimSpace(d.Description) == "" && len(d.Attachments) == 0
+}
+
+// DisplayName returns the name of the draft, falling back to its title.
+func (d Draft) DisplayName() string {
+	if d.Name != "" {
+		return d.Name
+	}
+	if d.Title != "" {
+		return d.Title
+	}
+	return d.ID
+}
+
+// LoadDrafts returns the saved drafts queue.
+func LoadDrafts(prefs fyne.Preferences) []Draft {
+	var drafts []Draft
+	if raw := prefs.String("issue_drafts"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &drafts); err != nil {
+			fmt.Println("Error reading drafts:", err)
+			return nil
+		}
+	}
+	return drafts
+}
+
+// SaveDrafts stores the drafts queue.
+func SaveDrafts(prefs fyne.Preferences, drafts []Draft) error {
+	data, err := json.Marshal(drafts)
+	if err != nil {
+		return err
+	}
+	prefs.SetString("issue_drafts", string(data))
+	return nil
+}
+
+// UpsertDraft adds the draft to the queue or replaces the one with the same ID.
+// Drafts without ID get one assigned, the stored draft is returned.
+func UpsertDraft(prefs fyne.Preferences, draft Draft) (Draft, error) {
+	if draft.ID == "" {
+		draft.ID = strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	draft.Updated = time.Now()
+
+	drafts := LoadDrafts(prefs)
+	replaced := false
+	for i := range drafts {
+		if drafts[i].ID == draft.ID {
+			drafts[i] = draft
+			replaced = true
+		}
+	}
+	if !replaced {
+		drafts = append(drafts, draft)
+	}
+	return draft, SaveDrafts(prefs, drafts)
+}
+
+// DeleteDraft removes the draft with the given ID from the queue.
+func DeleteDraft(prefs fyne.Preferences, id string) error {
+	drafts := LoadDrafts(prefs)
+	out := drafts[:0]
+	for _, d := range drafts {
+		if d.ID != id {
+			out = append(out, d)
+		}
+	}
+	return SaveDrafts(prefs, out)
+}
+
+// AttachmentsInUse returns the images the saved drafts refer to and, with autosave,
+// those of the autosaved create form.
+func AttachmentsInUse(prefs fyne.Preferences, autosave bool) map[string]bool {
+	drafts := LoadDrafts(prefs)
+	if d, ok := LoadAutosave(prefs); ok && autosave {
+		drafts = append(drafts, d)
+	}
+	inUse := map[string]bool{}
+	for _, d := range drafts {
+		for _, p := range d.Attachments {
+			inUse[p] = true
+		}
+	}
+	return inUse
+}
+
+// LoadAutosave returns the automatically saved state of the create form, if any.
+func LoadAutosave(prefs fyne.Preferences) (Draft, bool) {
+	raw := prefs.String("draft_autosave")
+	if raw == "" {
+		return Draft{}, false
+	}
+	var draft Draft
+	if err := json.Unmarshal([]byte(raw), &draft); err != nil {
+		fmt.Println("Error reading autosaved draft:", err)
+		return Draft{}, false
+	}
+	return draft, !draft.IsEmpty()
+}
+
+// SaveAutosave stores the current state of the create form. Empty drafts clear it.
+func SaveAutosave(prefs fyne.Preferences, draft Draft) {
+	if draft.IsEmpty() {
+		prefs.RemoveValue("draft_autosave")
+		return
+	}
+	draft.Updated = time.Now()
+	data, err := json.Marshal(draft)
+	if err != nil {
+		fmt.Println("Error saving draft:", err)
+		return
+	}
+	prefs.SetString("draft_autosave", string(data))
+}
+
+// CreateDraftIssue creates the issue described by a draft.
+// Fields of the create screen beyond title, description, labels and parent are not part of drafts.
+func CreateDraftIssue(domain, email, token string, draft Draft) (CreatedIssue, error) {
+	var fields map[string]interface{}
+	if draft.ParentKey != "" {
+		fields = map[string]interface{}{"parent": map[string]string{"key": draft.ParentKey}}
+	}
+	created, err := CreateJiraIssue(domain, email, token, draft.Project, draft.IssueType, draft.Title, draft.Description, draft.Labels, fields)
+	if err != nil || len(draft.Attachments) == 0 {
+		return created, err
+	}
+	if err := AttachInlineImages(domain, email, token, created.Key, draft.Description, draft.Attachments); err != nil {
+		return created, fmt.Errorf("%s was created, but attaching images failed: %w", created.Key, err)
+	}
+	return created, nil
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MinDuplicateScore hides search hits whose summary is too different from the title.
+const MinDuplicateScore = 25
+
+// DuplicateCandidate is an existing issue that may describe the same thing as a new title.
+type DuplicateCandidate struct {
+	Issue JiraIssue
+	// Score is the similarity of the summaries in percent.
+	Score int
+}
+
+// FindDuplicates searches the project for issues similar to the given title,
+// best matches first.
+func FindDuplicates(domain, email, token, projectKey, title string) ([]DuplicateCandidate, error) {
+	words := titleWords(title)
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/search/jql", domain)
+	jql := fmt.Sprintf(`project = "%s" AND text ~ "%s" ORDER BY updated DESC`, projectKey, strings.Join(words, " "))
+	body := map[string]interface{}{
+		"jql":        jql,
+		"fields":     []string{"summary", "status", "issuetype"},
+		"maxResults": 20,
+	}
+
+	var result JiraSearchResult
+	if err := jiraCall("POST", url, email, token, body, &result); err != nil {
+		return nil, err
+	}
+
+	var candidates []DuplicateCandidate
+	for _, iss := range result.Issues {
+		score := TitleSimilarity(title, iss.Fields.Summary)
+		if score >= MinDuplicateScore {
+			candidates = append(candidates, DuplicateCandidate{Issue: iss, Score: score})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	return candidates, nil
+}
+
+// TitleSimilarity compares the words of two titles (Dice coefficient) and returns 0-100.
+func TitleSimilarity(a, b string) int {
+	wordsA := titleWords(a)
+	wordsB := titleWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	inB := map[string]bool{}
+	for _, w := range wordsB {
+		inB[w] = true
+	}
+	common := 0
+	for _, w := range wordsA {
+		if inB[w] {
+			common++
+		}
+	}
+	return common * 200 / (len(wordsA) + len(wordsB))
+}
+
+// titleWords returns the distinct lower-case words of a title. Everything else is dropped,
+// which also keeps JQL and Lucene special characters out of the text search.
+func titleWords(title string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	seen := map[string]bool{}
+	var words []string
+	for _, f := range fields {
+		if len([]rune(f)) < 2 || seen[f] {
+			continue
+		}
+		seen[f] = true
+		words = append(words, f)
+	}
+	return words
+}
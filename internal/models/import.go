@@ -0,0 +1,500 @@
+package models
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Import targets that are not createmeta fields.
+const (
+	ImportIgnore      = ""
+	ImportSummary     = "summary"
+	ImportDescription = "description"
+	ImportLabels      = "labels"
+	ImportIssueType   = "issuetype"
+)
+
+// BulkCreateBatchSize is the maximum number of issues Jira accepts per POST /issue/bulk.
+const BulkCreateBatchSize = 50
+
+// ImportTable is the parsed content of an import file.
+type ImportTable struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// Value returns the cell of the given row and column, or "" if the row is shorter.
+func (t ImportTable) Value(row, col int) string {
+	if col < len(t.Rows[row]) {
+		return strings.TrimSpace(t.Rows[row][col])
+	}
+	return ""
+}
+
+// ParseImportFile parses a CSV, Markdown or JSON file, chosen by the file extension.
+func ParseImportFile(name string, r io.Reader) (ImportTable, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return ParseCSVImport(r)
+	case ".md", ".markdown", ".txt":
+		return ParseMarkdownImport(r)
+	case ".json":
+		return ParseJSONImport(r)
+	}
+	return ImportTable{}, fmt.Errorf("unsupported import file: %s", name)
+}
+
+// ParseCSVImport reads a CSV file with a header row. Comma and semicolon separators are detected.
+func ParseCSVImport(r io.Reader) (ImportTable, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return ImportTable{}, err
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff")
+
+	reader := csv.NewReader(strings.NewReader(text))
+	firstLine, _, _ := strings.Cut(text, "\n")
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return ImportTable{}, err
+	}
+	if len(records) < 2 {
+		return ImportTable{}, errors.New("the CSV file needs a header row and at least one issue")
+	}
+	return ImportTable{Columns: records[0], Rows: records[1:]}, nil
+}
+
+// ParseMarkdownImport reads a Markdown list or checklist. Every top-level item becomes
+// an issue, indented lines below it become its description.
+func ParseMarkdownImport(r io.Reader) (ImportTable, error) {
+	table := ImportTable{Columns: []string{ImportSummary, ImportDescription}}
+	var description []string
+
+	flush := func() {
+		if len(table.Rows) > 0 {
+			table.Rows[len(table.Rows)-1][1] = strings.TrimSpace(strings.Join(description, "\n"))
+		}
+		description = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			if len(description) > 0 {
+				description = append(description, "")
+			}
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			if len(table.Rows) > 0 {
+				description = append(description, strings.TrimSpace(line))
+			}
+			continue
+		}
+		if summary, ok := markdownItem(line); ok {
+			flush()
+			table.Rows = append(table.Rows, []string{summary, ""})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return ImportTable{}, err
+	}
+	flush()
+
+	if len(table.Rows) == 0 {
+		return ImportTable{}, errors.New("no list items found")
+	}
+	return table, nil
+}
+
+// markdownItem returns the text of a list item ("- ", "* ", "1. ", optionally "[ ] ").
+func markdownItem(line string) (string, bool) {
+	text := line
+	switch {
+	case strings.HasPrefix(text, "- "), strings.HasPrefix(text, "* "), strings.HasPrefix(text, "+ "):
+		text = text[2:]
+	default:
+		dot := strings.Index(text, ". ")
+		if dot <= 0 {
+			return "", false
+		}
+		if _, err := strconv.Atoi(text[:dot]); err != nil {
+			return "", false
+		}
+		text = text[dot+2:]
+	}
+	for _, box := range []string{"[ ] ", "[x] ", "[X] "} {
+		text = strings.TrimPrefix(text, box)
+	}
+	text = strings.TrimSpace(text)
+	return text, text != ""
+}
+
+// ParseJSONImport reads an array of objects. Every key becomes a column,
+// arrays are joined with spaces.
+func ParseJSONImport(r io.Reader) (ImportTable, error) {
+	var objects []map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&objects); err != nil {
+		return ImportTable{}, fmt.Errorf("invalid JSON import (expected an array of objects): %w", err)
+	}
+	if len(objects) == 0 {
+		return ImportTable{}, errors.New("the JSON file contains no issues")
+	}
+
+	seen := map[string]bool{}
+	var columns []string
+	for _, obj := range objects {
+		for key := range obj {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+	}
+	sort.Strings(columns)
+
+	table := ImportTable{Columns: columns}
+	for _, obj := range objects {
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			row[i] = jsonImportValue(obj[col])
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table, nil
+}
+
+func jsonImportValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []interface{}:
+		var parts []string
+		for _, item := range value {
+			parts = append(parts, jsonImportValue(item))
+		}
+		return strings.Join(parts, " ")
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// ImportRowIssueType returns the issue type of a row: the mapped issue type column or defaultType.
+func ImportRowIssueType(table ImportTable, row int, mapping []string, defaultType string) string {
+	for col, target := range mapping {
+		if target == ImportIssueType {
+			if value := table.Value(row, col); value != "" {
+				return value
+			}
+		}
+	}
+	return defaultType
+}
+
+// ImportIssue is a row converted into create fields.
+type ImportIssue struct {
+	Row       int
+	Summary   string
+	IssueType string
+	Fields    map[string]interface{}
+	Errors    []string
+}
+
+// BuildImportIssue converts a table row into create fields. mapping assigns a target
+// (field ID or one of the Import* constants) to every column. fields are the createmeta
+// fields of the row's issue type (see ImportRowIssueType) and are used to validate and convert the values.
+func BuildImportIssue(table ImportTable, row int, mapping []string, projectKey, issueType string, fields []JiraField) ImportIssue {
+	issue := ImportIssue{
+		Row:       row,
+		IssueType: issueType,
+		Fields: map[string]interface{}{
+			"project": map[string]string{"key": projectKey},
+		},
+	}
+
+	byID := map[string]JiraField{}
+	for _, f := range fields {
+		byID[f.ID()] = f
+	}
+
+	var labels []string
+	for col, target := range mapping {
+		value := table.Value(row, col)
+		if target == ImportIgnore || value == "" {
+			continue
+		}
+		switch target {
+		case ImportSummary:
+			issue.Summary = value
+		case ImportDescription:
+			issue.Fields["description"] = TextToADF(value)
+		case ImportLabels:
+			labels = append(labels, strings.Fields(strings.ReplaceAll(value, ",", " "))...)
+		case ImportIssueType:
+			issue.IssueType = value
+		default:
+			f, ok := byID[target]
+			if !ok {
+				issue.Errors = append(issue.Errors, fmt.Sprintf("%s: not on the create screen of %s", table.Columns[col], issue.IssueType))
+				continue
+			}
+			v, err := ImportFieldValue(f, value)
+			if err != nil {
+				issue.Errors = append(issue.Errors, fmt.Sprintf("%s: %v", f.Name, err))
+				continue
+			}
+			issue.Fields[f.ID()] = v
+		}
+	}
+
+	issue.Fields["issuetype"] = map[string]string{"name": issue.IssueType}
+	if issue.Summary == "" {
+		issue.Errors = append(issue.Errors, "summary is missing")
+	}
+	issue.Fields["summary"] = issue.Summary
+	if len(labels) > 0 {
+		issue.Fields["labels"] = labels
+	}
+
+	for _, f := range fields {
+		if !f.Required || f.HasDefaultValue {
+			continue
+		}
+		if _, ok := issue.Fields[f.ID()]; !ok {
+			issue.Errors = append(issue.Errors, fmt.Sprintf("%s is required", f.Name))
+		}
+	}
+	return issue
+}
+
+// ImportFieldValue converts text into the payload value of a createmeta field.
+// Allowed values are matched by name, users are given by account ID.
+func ImportFieldValue(f JiraField, text string) (interface{}, error) {
+	schema := f.Schema
+	switch {
+	case schema.Type == "option-with-child":
+		parentName, childName, hasChild := strings.Cut(text, ">")
+		parent, ok := findAllowedValue(f.AllowedValues, parentName)
+		if !ok {
+			return nil, fmt.Errorf("unknown value %q", strings.TrimSpace(parentName))
+		}
+		value := map[string]interface{}{"id": parent.ID}
+		if hasChild {
+			child, ok := findAllowedValue(parent.Children, childName)
+			if !ok {
+				return nil, fmt.Errorf("unknown value %q", strings.TrimSpace(childName))
+			}
+			value["child"] = map[string]string{"id": child.ID}
+		}
+		return value, nil
+	case schema.Type == "user":
+		return map[string]string{"accountId": text}, nil
+	case schema.Type == "array" && schema.Items == "user":
+		var users []map[string]string
+		for _, id := range strings.Split(text, ",") {
+			users = append(users, map[string]string{"accountId": strings.TrimSpace(id)})
+		}
+		return users, nil
+	case schema.Type == "array" && len(f.AllowedValues) > 0:
+		var values []map[string]string
+		for _, name := range strings.Split(text, ",") {
+			v, ok := findAllowedValue(f.AllowedValues, name)
+			if !ok {
+				return nil, fmt.Errorf("unknown value %q", strings.TrimSpace(name))
+			}
+			values = append(values, map[string]string{"id": v.ID})
+		}
+		return values, nil
+	case schema.Type == "array" && schema.Items == "string":
+		return strings.Fields(strings.ReplaceAll(text, ",", " ")), nil
+	case len(f.AllowedValues) > 0:
+		v, ok := findAllowedValue(f.AllowedValues, text)
+		if !ok {
+			return nil, fmt.Errorf("unknown value %q", text)
+		}
+		return map[string]string{"id": v.ID}, nil
+	case schema.Type == "date":
+		if _, err := time.Parse("2006-01-02", text); err != nil {
+			return nil, errors.New("expected a date like 2006-01-02")
+		}
+		return text, nil
+	case schema.Type == "datetime":
+		t, err := time.ParseInLocation("2006-01-02 15:04", text, time.Local)
+		if err != nil {
+			return nil, errors.New("expected a date like 2006-01-02 15:04")
+		}
+		return t.Format("2006-01-02T15:04:05.000-0700"), nil
+	case schema.Type == "number":
+		n, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", "."), 64)
+		if err != nil {
+			return nil, errors.New("expected a number")
+		}
+		return n, nil
+	case schema.Type == "string":
+		if strings.HasSuffix(schema.Custom, ":textarea") || schema.System == "environment" {
+			return TextToADF(text), nil
+		}
+		return text, nil
+	}
+	return nil, fmt.Errorf("field type %q is not supported", schema.Type)
+}
+
+func findAllowedValue(values []JiraFieldValue, name string) (JiraFieldValue, bool) {
+	name = strings.TrimSpace(name)
+	for _, v := range values {
+		if strings.EqualFold(v.Label(), name) || v.ID == name {
+			return v, true
+		}
+	}
+	return JiraFieldValue{}, false
+}
+
+// BulkCreateResult is the outcome of creating one issue via CreateIssuesBulk.
+type BulkCreateResult struct {
+	Key string
+	Err error
+}
+
+type bulkCreateResponse struct {
+	Issues []struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	} `json:"issues"`
+	Errors []struct {
+		Status              int             `json:"status"`
+		ElementErrors       json.RawMessage `json:"elementErrors"`
+		FailedElementNumber int             `json:"failedElementNumber"`
+	} `json:"errors"`
+}
+
+// CreateIssuesBulk creates the issues in batches of BulkCreateBatchSize. The results are
+// returned in input order; progress is called after every batch with the number of done issues.
+func CreateIssuesBulk(domain, email, token string, issues []map[string]interface{}, progress func(done int)) []BulkCreateResult {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/bulk", domain)
+	results := make([]BulkCreateResult, len(issues))
+
+	for start := 0; start < len(issues); start += BulkCreateBatchSize {
+		end := start + BulkCreateBatchSize
+		if end > len(issues) {
+			end = len(issues)
+		}
+
+		var updates []map[string]interface{}
+		for _, fields := range issues[start:end] {
+			updates = append(updates, map[string]interface{}{"fields": fields})
+		}
+
+		data, err := postBulkCreate(url, email, token, updates)
+		if err != nil {
+			for i := start; i < end; i++ {
+				results[i].Err = err
+			}
+		} else {
+			failed := map[int]error{}
+			for _, e := range data.Errors {
+				failed[e.FailedElementNumber] = errors.New(jiraErrorMessage(e.ElementErrors))
+			}
+			created := 0
+			for i := start; i < end; i++ {
+				if e, ok := failed[i-start]; ok {
+					results[i].Err = e
+					continue
+				}
+				if created < len(data.Issues) {
+					results[i].Key = data.Issues[created].Key
+					created++
+				}
+			}
+		}
+
+		if progress != nil {
+			progress(end)
+		}
+	}
+	return results
+}
+
+// postBulkCreate sends one batch. Jira answers partial failures with 400 and a body
+// listing both the created issues and the failed elements, so that body is decoded as well.
+func postBulkCreate(url, email, token string, updates []map[string]interface{}) (bulkCreateResponse, error) {
+	var data bulkCreateResponse
+	req, err := newJiraRequest("POST", url, email, token, map[string]interface{}{"issueUpdates": updates})
+	if err != nil {
+		return data, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return data, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return data, err
+	}
+	if jsonErr := json.Unmarshal(body, &data); jsonErr == nil && (res.StatusCode == http.StatusCreated || len(data.Errors) > 0) {
+		return data, nil
+	}
+	return data, fmt.Errorf("jira api error (%d): %s", res.StatusCode, jiraErrorMessage(body))
+}
+
+// WriteImportReport writes the original rows as CSV with the created key, its link and the errors.
+func WriteImportReport(w io.Writer, domain string, table ImportTable, issues []ImportIssue, results []BulkCreateResult, dryRun bool) error {
+	writer := csv.NewWriter(w)
+	header := append(append([]string{}, table.Columns...), "result", "key", "url", "error")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	resultIndex := 0
+	for _, iss := range issues {
+		row := make([]string, len(table.Columns))
+		copy(row, table.Rows[iss.Row])
+
+		var status, key, link, message string
+		switch {
+		case len(iss.Errors) > 0:
+			status = "invalid"
+			message = strings.Join(iss.Errors, "; ")
+		case dryRun:
+			status = "valid"
+		default:
+			res := results[resultIndex]
+			resultIndex++
+			if res.Err != nil {
+				status = "failed"
+				message = res.Err.Error()
+			} else {
+				status = "created"
+				key = res.Key
+				link = IssueBrowseURL(domain, res.Key)
+			}
+		}
+		if err := writer.Write(append(row, status, key, link, message)); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -8,7 +8,6 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"sort"
 	"strings"
 )
 
@@ -18,9 +17,9 @@ type JiraIssue struct {
 	Fields struct {
 		Summary     string          `json:"summary"`
 		Description json.RawMessage `json:"description"`
-		IssueType   struct {
-			Name string `json:"name"`
-		} `json:"issuetype"`
+		IssueType   JiraIssueType   `json:"issuetype"`
+		Status      JiraStatus      `json:"status"`
+		Priority    JiraPriority    `json:"priority"`
 	} `json:"fields"`
 }
 
@@ -37,7 +36,7 @@ type JiraIssueLabels struct {
 }
 
 type JiraCommentResult struct {
-	Comments []JiraComment `json:comment`
+	Comments []JiraComment `json:"comments"`
 }
 
 type JiraComment struct {
@@ -75,8 +74,12 @@ type JiraProjectResult struct {
 }
 
 type JiraIssueType struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	IconURL string `json:"iconUrl"`
+	Subtask bool   `json:"subtask"`
+	// HierarchyLevel is 1 for epics, 0 for standard issues and -1 for sub-tasks.
+	HierarchyLevel int `json:"hierarchyLevel"`
 }
 
 type JiraCreateMetaResponse struct {
@@ -115,7 +118,7 @@ func FetchIssueTransitions(domain, email, token string, id string) ([]JiraTransi
 func FetchAssignedIssues(domain, email, apiToken string) ([]JiraIssue, error) {
 	jql := `assignee=currentUser() AND status NOT IN ("Done", "Canceled", "Cancelled", "Approved")`
 	encodedJQL := url.QueryEscape(jql)
-	params := "fields=id,summary,issuetype,key,description"
+	params := "fields=id,summary,issuetype,key,description,status,priority"
 	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/search/jql?jql=%s&%s", domain, encodedJQL, params)
 	req, _ := http.NewRequest("GET", url, nil)
 
@@ -266,6 +269,16 @@ func FetchIssueLabels(domain, email, token string, id string) (JiraIssueLabels,
 	return result, nil
 }
 
+// FetchIssue loads a single issue by key or ID, independent of who it is assigned to.
+func FetchIssue(domain, email, token, key string) (JiraIssue, error) {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s?fields=summary,description,issuetype,status,priority", domain, key)
+	var out JiraIssue
+	if err := jiraCall("GET", url, email, token, nil, &out); err != nil {
+		return JiraIssue{}, err
+	}
+	return out, nil
+}
+
 func FetchFavouriteProjects(domain, email, token string) ([]JiraProject, error) {
 	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/project/search?favourite=true", domain)
 	req, _ := http.NewRequest("GET", url, nil)
@@ -292,7 +305,16 @@ func FetchFavouriteProjects(domain, email, token string) ([]JiraProject, error)
 	return result.Values, nil
 }
 
-func CreateJiraIssue(domain, email, token, projectKey, issueType, title, content string, labels []string) error {
+// CreatedIssue is the response of Jira when an issue was created.
+type CreatedIssue struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Self string `json:"self"`
+}
+
+// CreateJiraIssue creates an issue and returns its key and ID. fields holds additional field
+// values from the create screen (see FetchCreateMetaFields), keyed by field ID.
+func CreateJiraIssue(domain, email, token, projectKey, issueType, title, content string, labels []string, fields map[string]interface{}) (CreatedIssue, error) {
 	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue", domain)
 
 	// Beschreibung im Atlassian Document Format (ADF)
@@ -312,23 +334,28 @@ func CreateJiraIssue(domain, email, token, projectKey, issueType, title, content
 		},
 	}
 
-	body := map[string]interface{}{
-		"fields": map[string]interface{}{
-			"project": map[string]string{
-				"key": projectKey,
-			},
-			"issuetype": map[string]string{
-				"name": issueType,
-			},
-			"summary":     title,
-			"description": adf,
-			"labels":      labels,
+	issueFields := map[string]interface{}{
+		"project": map[string]string{
+			"key": projectKey,
+		},
+		"issuetype": map[string]string{
+			"name": issueType,
 		},
+		"summary":     title,
+		"description": adf,
+		"labels":      labels,
+	}
+	for id, value := range fields {
+		issueFields[id] = value
+	}
+
+	body := map[string]interface{}{
+		"fields": issueFields,
 	}
 
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
-		return err
+		return CreatedIssue{}, err
 	}
 
 	req, _ := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
@@ -340,16 +367,20 @@ func CreateJiraIssue(domain, email, token, projectKey, issueType, title, content
 
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return err
+		return CreatedIssue{}, err
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode != 201 {
 		b, _ := io.ReadAll(res.Body)
-		return fmt.Errorf("failed to create issue: %s", string(b))
+		return CreatedIssue{}, fmt.Errorf("failed to create issue: %s", jiraErrorMessage(b))
 	}
 
-	return nil
+	var created CreatedIssue
+	if err := json.NewDecoder(res.Body).Decode(&created); err != nil {
+		return CreatedIssue{}, err
+	}
+	return created, nil
 }
 
 func FetchProjectIssueTypes(domain, email, token, projectKey string) ([]JiraIssueType, error) {
@@ -383,14 +414,6 @@ func FetchProjectIssueTypes(domain, email, token, projectKey string) ([]JiraIssu
 	return data.Projects[0].IssueTypes, nil
 }
 
-type jiraSearchResult struct {
-	Issues []struct {
-		Fields struct {
-			Labels []string `json:"labels"`
-		} `json:"fields"`
-	} `json:"issues"`
-}
-
 // FetchAllProjects returns all visible projects (first page) for the user
 func FetchAllProjects(domain, email, token string) ([]JiraProject, error) {
 	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/project/search?favourite=true", domain)
@@ -418,58 +441,6 @@ func FetchAllProjects(domain, email, token string) ([]JiraProject, error) {
 	return out.Values, nil
 }
 
-// FetchProjectLabels queries all issues in a project and aggregates unique labels
-func FetchProjectLabels(domain, email, token, projectKey string) ([]string, error) {
-	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/search/jql", domain)
-
-	body := map[string]interface{}{
-		"jql":        fmt.Sprintf("project=%s", projectKey),
-		"fields":     []string{"labels"},
-		"maxResults": 1000,
-	}
-	jsonBody, _ := json.Marshal(body)
-
-	req, _ := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
-	decryptedToken := TryDecrypt(token)
-	auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", email, decryptedToken)))
-	req.Header.Add("Authorization", "Basic "+auth)
-	req.Header.Add("Accept", "application/json")
-	req.Header.Add("Content-Type", "application/json")
-
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode != http.StatusOK {
-		b, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("jira api error (%d): %s", res.StatusCode, string(b))
-	}
-
-	var data jiraSearchResult
-	if err := json.NewDecoder(res.Body).Decode(&data); err != nil {
-		return nil, err
-	}
-
-	set := map[string]struct{}{}
-	for _, iss := range data.Issues {
-		for _, l := range iss.Fields.Labels {
-			if l == "" {
-				continue
-			}
-			set[l] = struct{}{}
-		}
-	}
-
-	labels := make([]string, 0, len(set))
-	for k := range set {
-		labels = append(labels, k)
-	}
-	sort.Strings(labels)
-	return labels, nil
-}
-
 // JiraServiceDesk represents a service desk within Jira Service Management
 type JiraServiceDesk struct {
 	ID   string `json:"id"`
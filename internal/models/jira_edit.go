@@ -0,0 +1,79 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+)
+
+type JiraUser struct {
+	AccountID   string `json:"accountId"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"emailAddress"`
+}
+
+// TransitionIssue moves an issue through the workflow using the given transition ID.
+func TransitionIssue(domain, email, token, issueId, transitionID string) error {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s/transitions", domain, issueId)
+	payload := map[string]interface{}{
+		"transition": map[string]string{"id": transitionID},
+	}
+	return jiraCall("POST", url, email, token, payload, nil)
+}
+
+// UpdateIssueLabels adds and removes labels without touching the remaining ones.
+func UpdateIssueLabels(domain, email, token, issueId string, add, remove []string) error {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s", domain, issueId)
+	ops := []map[string]string{}
+	for _, l := range add {
+		ops = append(ops, map[string]string{"add": l})
+	}
+	for _, l := range remove {
+		ops = append(ops, map[string]string{"remove": l})
+	}
+	payload := map[string]interface{}{
+		"update": map[string]interface{}{"labels": ops},
+	}
+	return jiraCall("PUT", url, email, token, payload, nil)
+}
+
+// AssignIssue assigns an issue to the given account. An empty accountID unassigns it.
+func AssignIssue(domain, email, token, issueId, accountID string) error {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s/assignee", domain, issueId)
+	var payload map[string]interface{}
+	if accountID == "" {
+		payload = map[string]interface{}{"accountId": nil}
+	} else {
+		payload = map[string]interface{}{"accountId": accountID}
+	}
+	return jiraCall("PUT", url, email, token, payload, nil)
+}
+
+// SetIssuePriority changes the priority of an issue.
+func SetIssuePriority(domain, email, token, issueId, priorityID string) error {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s", domain, issueId)
+	payload := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"priority": map[string]string{"id": priorityID},
+		},
+	}
+	return jiraCall("PUT", url, email, token, payload, nil)
+}
+
+// SetIssueEstimate writes an estimate, e.g. story points, to the board's estimation field.
+func SetIssueEstimate(domain, email, token, issueId, field string, estimate float64) error {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s", domain, issueId)
+	payload := map[string]interface{}{
+		"fields": map[string]interface{}{field: estimate},
+	}
+	return jiraCall("PUT", url, email, token, payload, nil)
+}
+
+// SearchUsers finds active users by name or email.
+func SearchUsers(domain, email, token, query string) ([]JiraUser, error) {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/user/search?query=%s", domain, url.QueryEscape(query))
+	var out []JiraUser
+	if err := jiraCall("GET", u, email, token, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// newJiraRequest builds an authenticated Jira request. A non-nil payload is sent as JSON body.
+func newJiraRequest(method, url, email, token string, payload interface{}) (*http.Request, error) {
+	var body io.Reader
+	if payload != nil {
+		jsonBody, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(jsonBody)
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	decryptedToken := TryDecrypt(token)
+	auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", email, decryptedToken)))
+	req.Header.Add("Authorization", "Basic "+auth)
+	req.Header.Add("Accept", "application/json")
+	if payload != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// doJiraRequest executes the request and decodes the JSON response into out (if not nil).
+// Every 2xx status is treated as success, since Jira answers edits with 204 No Content.
+func doJiraRequest(req *http.Request, out interface{}) error {
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		b, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("jira api error (%d): %s", res.StatusCode, jiraErrorMessage(b))
+	}
+
+	if out == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// jiraCall is a shorthand for newJiraRequest followed by doJiraRequest.
+func jiraCall(method, url, email, token string, payload, out interface{}) error {
+	req, err := newJiraRequest(method, url, email, token, payload)
+	if err != nil {
+		return err
+	}
+	return doJiraRequest(req, out)
+}
+
+// jiraErrorMessage turns Jira's error response ({"errorMessages": [...], "errors": {...}})
+// into a readable message. Other bodies are returned as they are.
+func jiraErrorMessage(body []byte) string {
+	var data struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil || len(data.ErrorMessages)+len(data.Errors) == 0 {
+		return string(body)
+	}
+
+	messages := append([]string{}, data.ErrorMessages...)
+	fields := make([]string, 0, len(data.Errors))
+	for field := range data.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, fmt.Sprintf("%s: %s", field, data.Errors[field]))
+	}
+	return strings.Join(messages, "\n")
+}
@@ -0,0 +1,199 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// labelFavouritesVersion is the version of the export format.
+const labelFavouritesVersion = 1
+
+// LabelGroupColors are the colours offered for label groups, by name.
+var LabelGroupColors = []struct{ Name, Hex string }{
+	{"Grey", "#6b778c"},
+	{"Blue", "#0052cc"},
+	{"Teal", "#00a3bf"},
+	{"Green", "#36b37e"},
+	{"Yellow", "#ffab00"},
+	{"Orange", "#ff7a00"},
+	{"Red", "#de350b"},
+	{"Purple", "#6554c0"},
+}
+
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// LabelGroup is an ordered set of favourite labels, e.g. "Area" or "Team".
+type LabelGroup struct {
+	Name   string   `json:"name" yaml:"name"`
+	Color  string   `json:"color,omitempty" yaml:"color,omitempty"`
+	Labels []string `json:"labels" yaml:"labels"`
+}
+
+// LabelFavourites are the favourite labels of a project, offered first when creating issues.
+type LabelFavourites struct {
+	Version int          `json:"version" yaml:"version"`
+	Project string       `json:"project,omitempty" yaml:"project,omitempty"`
+	Groups  []LabelGroup `json:"groups" yaml:"groups"`
+}
+
+// All returns the labels of all groups in order.
+func (f LabelFavourites) All() []string {
+	var labels []string
+	seen := map[string]bool{}
+	for _, g := range f.Groups {
+		for _, l := range g.Labels {
+			if !seen[l] {
+				seen[l] = true
+				labels = append(labels, l)
+			}
+		}
+	}
+	return labels
+}
+
+// Group returns the index of the group containing the label, or -1.
+func (f LabelFavourites) Group(label string) int {
+	for i, g := range f.Groups {
+		for _, l := range g.Labels {
+			if l == label {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Color returns the colour of the label's group, "" if it has none.
+func (f LabelFavourites) Color(label string) string {
+	if i := f.Group(label); i >= 0 {
+		return f.Groups[i].Color
+	}
+	return ""
+}
+
+// Add appends the label to the group with the given index, moving it if it is in another group.
+func (f *LabelFavourites) Add(group int, label string) {
+	f.Remove(label)
+	f.Groups[group].Labels = append(f.Groups[group].Labels, label)
+}
+
+// Remove drops the label from all groups.
+func (f *LabelFavourites) Remove(label string) {
+	for i := range f.Groups {
+		out := f.Groups[i].Labels[:0]
+		for _, l := range f.Groups[i].Labels {
+			if l != label {
+				out = append(out, l)
+			}
+		}
+		f.Groups[i].Labels = out
+	}
+}
+
+// LoadLabelFavourites returns the favourites of the project. The former comma separated
+// "labels_<PROJECT>" preference is migrated into a single group on first access.
+func LoadLabelFavourites(prefs fyne.Preferences, projectKey string) LabelFavourites {
+	favs := LabelFavourites{Version: labelFavouritesVersion, Project: projectKey}
+	if raw := prefs.String("label_favourites_" + projectKey); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &favs); err != nil {
+			fmt.Println("Error reading label favourites:", err)
+		}
+		return favs
+	}
+
+	legacyKey := "labels_" + projectKey
+	if legacy := prefs.String(legacyKey); legacy != "" {
+		var labels []string
+		for _, l := range strings.Split(legacy, ",") {
+			if l = strings.TrimSpace(l); l != "" {
+				labels = append(labels, l)
+			}
+		}
+		favs.Groups = []LabelGroup{{Name: "Favourites", Labels: labels}}
+		if err := SaveLabelFavourites(prefs, projectKey, favs); err == nil {
+			prefs.RemoveValue(legacyKey)
+		}
+	}
+	return favs
+}
+
+// SaveLabelFavourites stores the favourites of the project. Empty groups are kept.
+func SaveLabelFavourites(prefs fyne.Preferences, projectKey string, favs LabelFavourites) error {
+	favs.Version = labelFavouritesVersion
+	favs.Project = projectKey
+	data, err := json.Marshal(favs)
+	if err != nil {
+		return err
+	}
+	prefs.SetString("label_favourites_"+projectKey, string(data))
+	return nil
+}
+
+// isYAMLFile reports whether the file name has a YAML extension.
+func isYAMLFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// ExportLabelFavourites writes the favourites as YAML if name ends in .yaml/.yml, else as JSON.
+func ExportLabelFavourites(w io.Writer, name string, favs LabelFavourites) error {
+	favs.Version = labelFavouritesVersion
+	if isYAMLFile(name) {
+		return writeLabelFavouritesYAML(w, favs)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(favs)
+}
+
+// ImportLabelFavourites reads a file written by ExportLabelFavourites.
+func ImportLabelFavourites(name string, r io.Reader) (LabelFavourites, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return LabelFavourites{}, err
+	}
+
+	var favs LabelFavourites
+	if isYAMLFile(name) {
+		err = yaml.Unmarshal(data, &favs)
+	} else {
+		err = json.Unmarshal(data, &favs)
+	}
+	if err != nil {
+		return LabelFavourites{}, fmt.Errorf("invalid label file: %w", err)
+	}
+	return validLabelFavourites(favs)
+}
+
+func validLabelFavourites(favs LabelFavourites) (LabelFavourites, error) {
+	if len(favs.Groups) == 0 {
+		return LabelFavourites{}, fmt.Errorf("the label file contains no groups")
+	}
+	for i, g := range favs.Groups {
+		if g.Color != "" && !hexColor.MatchString(g.Color) {
+			return LabelFavourites{}, fmt.Errorf("group %d: colour %q is not like #rrggbb", i+1, g.Color)
+		}
+		for _, l := range g.Labels {
+			if err := ValidateLabel(l); err != nil {
+				return LabelFavourites{}, fmt.Errorf("group %d, label %q: %w", i+1, l, err)
+			}
+		}
+	}
+	return favs, nil
+}
+
+func writeLabelFavouritesYAML(w io.Writer, favs LabelFavourites) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(favs); err != nil {
+		return err
+	}
+	return enc.Close()
+}
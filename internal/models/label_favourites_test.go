@@ -0,0 +1,90 @@
+package models
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportLabelFavouritesYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		want    []LabelGroup
+		wantErr bool
+	}{
+		{
+			name: "block lists",
+			doc:  "version: 1\ngroups:\n  - name: Area\n    color: \"#0052cc\"\n    labels:\n      - backend\n      - frontend\n",
+			want: []LabelGroup{{Name: "Area", Color: "#0052cc", Labels: []string{"backend", "frontend"}}},
+		},
+		{
+			name: "numbers and booleans stay labels",
+			doc:  "groups:\n- name: Release\n  labels:\n  - 2024\n  - true\n  - 1.5\n",
+			want: []LabelGroup{{Name: "Release", Labels: []string{"2024", "true", "1.5"}}},
+		},
+		{
+			name: "flow lists and comments",
+			doc:  "# shared by the team\ngroups: [{name: Team, labels: [blue, red]}] # end\n",
+			want: []LabelGroup{{Name: "Team", Labels: []string{"blue", "red"}}},
+		},
+		{
+			name:    "invalid colour",
+			doc:     "groups:\n  - name: Area\n    color: blue\n    labels: [x]\n",
+			wantErr: true,
+		},
+		{
+			name:    "label with space",
+			doc:     "groups:\n  - name: Area\n    labels: [\"two words\"]\n",
+			wantErr: true,
+		},
+		{
+			name:    "no groups",
+			doc:     "version: 1\n",
+			wantErr: true,
+		},
+		{
+			name:    "not yaml",
+			doc:     "groups: [\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			favs, err := ImportLabelFavourites("labels.yaml", strings.NewReader(tt.doc))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", favs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(favs.Groups, tt.want) {
+				t.Errorf("groups = %+v, want %+v", favs.Groups, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportLabelFavouritesRoundTrip(t *testing.T) {
+	favs := LabelFavourites{Project: "ABC", Groups: []LabelGroup{
+		{Name: "Release: next", Color: "#36b37e", Labels: []string{"2024", "null", "v1.0"}},
+		{Name: "Empty", Labels: []string{}},
+	}}
+	for _, name := range []string{"labels.yaml", "labels.json"} {
+		var buf bytes.Buffer
+		if err := ExportLabelFavourites(&buf, name, favs); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ImportLabelFavourites(name, &buf)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got.Project != favs.Project || len(got.Groups) != 2 || !reflect.DeepEqual(got.Groups[0], favs.Groups[0]) {
+			t.Errorf("%s: got %+v, want %+v", name, got, favs)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+)
+
+// maxLabelMergeRuns is the number of label merge runs kept in the log.
+const maxLabelMergeRuns = 20
+
+// LabelIssue is an issue with its current labels.
+type LabelIssue struct {
+	ID      string
+	Key     string
+	Summary string
+	Labels  []string
+}
+
+// LabelMergeEntry records the change of a single issue, so it can be resumed and undone.
+type LabelMergeEntry struct {
+	Key     string   `json:"key"`
+	Removed []string `json:"removed"`
+	// Added is set if the issue did not have the target label before.
+	Added  bool   `json:"added"`
+	Done   bool   `json:"done"`
+	Undone bool   `json:"undone"`
+	Error  string `json:"error,omitempty"`
+}
+
+// LabelMergeRun renames or merges labels of a project. A rename is a merge with one source.
+type LabelMergeRun struct {
+	ID      string            `json:"id"`
+	Project string            `json:"project"`
+	Sources []string          `json:"sources"`
+	Target  string            `json:"target"`
+	Started time.Time         `json:"started"`
+	Entries []LabelMergeEntry `json:"entries"`
+}
+
+// Progress returns how many entries are done and how many were undone.
+func (r LabelMergeRun) Progress() (done, undone int) {
+	for _, e := range r.Entries {
+		if e.Undone {
+			undone++
+		} else if e.Done {
+			done++
+		}
+	}
+	return done, undone
+}
+
+// SimilarLabels groups labels that differ only in case, separators or a typo, e.g.
+// "frontend", "Frontend" and "front-end". Labels are taken by usage; every label that
+// is not grouped yet becomes an anchor and only labels similar to the anchor itself
+// join its group, so series like "sprint-41", "sprint-42" are not chained together.
+// Groups are sorted by total usage and each group by usage, so the first label is the
+// anchor and the suggested target.
+func SimilarLabels(counts map[string]int) [][]string {
+	labels := SortedLabels(counts)
+	sort.SliceStable(labels, func(i, j int) bool { return counts[labels[i]] > counts[labels[j]] })
+
+	normalized := make([]string, len(labels))
+	for i, l := range labels {
+		normalized[i] = normalizeLabel(l)
+	}
+	grouped := make([]bool, len(labels))
+	var groups [][]string
+	total := map[int]int{}
+	for i := range labels {
+		if grouped[i] {
+			continue
+		}
+		group := []string{labels[i]}
+		sum := counts[labels[i]]
+		for j := i + 1; j < len(labels); j++ {
+			if !grouped[j] && labelsSimilar(normalized[i], normalized[j]) {
+				grouped[j] = true
+				group = append(group, labels[j])
+				sum += counts[labels[j]]
+			}
+		}
+		if len(group) < 2 {
+			continue
+		}
+		total[len(groups)] = sum
+		groups = append(groups, group)
+	}
+	order := make([]int, len(groups))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if total[order[i]] != total[order[j]] {
+			return total[order[i]] > total[order[j]]
+		}
+		return groups[order[i]][0] < groups[order[j]][0]
+	})
+	var sorted [][]string
+	for _, o := range order {
+		sorted = append(sorted, groups[o])
+	}
+	return sorted
+}
+
+// LabelsEquivalent reports whether the labels differ only in case and separators,
+// as opposed to a typo.
+func LabelsEquivalent(a, b string) bool {
+	return normalizeLabel(a) == normalizeLabel(b)
+}
+
+// normalizeLabel lower-cases the label and drops separators.
+func normalizeLabel(label string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == '.' || r == '/' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, label)
+}
+
+// labelsSimilar allows one typo in normalized labels of 5+ characters and two in 10+.
+// Labels with different numbers, e.g. "sprint41" and "sprint42", are never similar.
+func labelsSimilar(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if labelDigits(a) != labelDigits(b) {
+		return false
+	}
+	shorter := len([]rune(a))
+	if n := len([]rune(b)); n < shorter {
+		shorter = n
+	}
+	allowed := 0
+	switch {
+	case shorter >= 10:
+		allowed = 2
+	case shorter >= 5:
+		allowed = 1
+	}
+	return allowed > 0 && editDistance(a, b) <= allowed
+}
+
+// labelDigits returns the digits of the label.
+func labelDigits(label string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, label)
+}
+
+// editDistance is the Levenshtein distance of two strings.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// FetchLabelIssues returns all issues of the project that have one of the labels.
+func FetchLabelIssues(domain, email, token, projectKey string, labels []string) ([]LabelIssue, error) {
+	quoted := make([]string, len(labels))
+	for i, l := range labels {
+		quoted[i] = strconv.Quote(l)
+	}
+	jql := fmt.Sprintf(`%s AND labels in (%s) ORDER BY key ASC`, projectJQL(projectKey), strings.Join(quoted, ", "))
+
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/search/jql", domain)
+	var issues []LabelIssue
+	nextPageToken := ""
+	for {
+		body := map[string]interface{}{
+			"jql":        jql,
+			"fields":     []string{"summary", "labels"},
+			"maxResults": labelScanPageSize,
+		}
+		if nextPageToken != "" {
+			body["nextPageToken"] = nextPageToken
+		}
+		var page struct {
+			Issues []struct {
+				ID     string `json:"id"`
+				Key    string `json:"key"`
+				Fields struct {
+					Summary string   `json:"summary"`
+					Labels  []string `json:"labels"`
+				} `json:"fields"`
+			} `json:"issues"`
+			NextPageToken string `json:"nextPageToken"`
+			IsLast        bool   `json:"isLast"`
+		}
+		if err := jiraCall("POST", url, email, token, body, &page); err != nil {
+			return nil, err
+		}
+		for _, iss := range page.Issues {
+			issues = append(issues, LabelIssue{ID: iss.ID, Key: iss.Key, Summary: iss.Fields.Summary, Labels: iss.Fields.Labels})
+		}
+		if page.IsLast || page.NextPageToken == "" || len(page.Issues) == 0 {
+			return issues, nil
+		}
+		nextPageToken = page.NextPageToken
+	}
+}
+
+// PlanLabelMerge creates the run for the issues; issues that would not change are left out.
+func PlanLabelMerge(projectKey string, sources []string, target string, issues []LabelIssue) LabelMergeRun {
+	run := LabelMergeRun{
+		ID:      strconv.FormatInt(time.Now().UnixNano(), 36),
+		Project: projectKey,
+		Sources: sources,
+		Target:  target,
+		Started: time.Now(),
+	}
+	for _, iss := range issues {
+		entry := LabelMergeEntry{Key: iss.Key, Added: true}
+		for _, l := range iss.Labels {
+			if l == target {
+				entry.Added = false
+				continue
+			}
+			for _, s := range sources {
+				if l == s {
+					entry.Removed = append(entry.Removed, l)
+				}
+			}
+		}
+		if len(entry.Removed) > 0 {
+			run.Entries = append(run.Entries, entry)
+		}
+	}
+	return run
+}
+
+// ApplyLabelMerge changes the labels of all entries that are not done yet. The run is
+// saved after every issue, so an interrupted run can be resumed. progress is called
+// from worker goroutines with the number of finished entries.
+func ApplyLabelMerge(domain, email, token string, prefs fyne.Preferences, run *LabelMergeRun, progress func(done, total int)) []BulkResult {
+	return applyLabelMerge(prefs, run, func(key string, add, remove []string) error {
+		return UpdateIssueLabels(domain, email, token, key, add, remove)
+	}, progress)
+}
+
+// UndoLabelMerge restores the removed labels and removes the target label where it was added.
+func UndoLabelMerge(domain, email, token string, prefs fyne.Preferences, run *LabelMergeRun, progress func(done, total int)) []BulkResult {
+	return undoLabelMerge(prefs, run, func(key string, add, remove []string) error {
+		return UpdateIssueLabels(domain, email, token, key, add, remove)
+	}, progress)
+}
+
+// labelUpdater adds and removes labels of an issue.
+type labelUpdater func(key string, add, remove []string) error
+
+func applyLabelMerge(prefs fyne.Preferences, run *LabelMergeRun, update labelUpdater, progress func(done, total int)) []BulkResult {
+	return runLabelMergeEntries(prefs, run, func(e LabelMergeEntry) bool { return !e.Done && !e.Undone }, func(e *LabelMergeEntry) error {
+		var add []string
+		if e.Added {
+			add = []string{run.Target}
+		}
+		if err := update(e.Key, add, e.Removed); err != nil {
+			return err
+		}
+		e.Done = true
+		return nil
+	}, progress)
+}
+
+func undoLabelMerge(prefs fyne.Preferences, run *LabelMergeRun, update labelUpdater, progress func(done, total int)) []BulkResult {
+	return runLabelMergeEntries(prefs, run, func(e LabelMergeEntry) bool { return e.Done && !e.Undone }, func(e *LabelMergeEntry) error {
+		var remove []string
+		if e.Added {
+			remove = []string{run.Target}
+		}
+		if err := update(e.Key, e.Removed, remove); err != nil {
+			return err
+		}
+		e.Undone = true
+		return nil
+	}, progress)
+}
+
+func runLabelMergeEntries(prefs fyne.Preferences, run *LabelMergeRun, pending func(LabelMergeEntry) bool, op func(*LabelMergeEntry) error, progress func(done, total int)) []BulkResult {
+	index := map[string]int{}
+	var issues []JiraIssue
+	for i, e := range run.Entries {
+		if pending(e) {
+			index[e.Key] = i
+			issues = append(issues, JiraIssue{Key: e.Key})
+		}
+	}
+	SaveLabelMergeRun(prefs, *run)
+
+	var mu sync.Mutex
+	finished := 0
+	return RunBulk(issues, func(iss JiraIssue) error {
+		mu.Lock()
+		entry := run.Entries[index[iss.Key]]
+		mu.Unlock()
+
+		err := op(&entry)
+		entry.Error = ""
+		if err != nil {
+			entry.Error = err.Error()
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		run.Entries[index[iss.Key]] = entry
+		SaveLabelMergeRun(prefs, *run)
+		finished++
+		if progress != nil {
+			progress(finished, len(issues))
+		}
+		return err
+	}, nil)
+}
+
+// LoadLabelMergeRuns returns the logged runs, newest first.
+func LoadLabelMergeRuns(prefs fyne.Preferences) []LabelMergeRun {
+	var runs []LabelMergeRun
+	if raw := prefs.String("label_merge_runs"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &runs); err != nil {
+			fmt.Println("Error reading label merge log:", err)
+			return nil
+		}
+	}
+	return runs
+}
+
+// SaveLabelMergeRun adds or updates the run in the log.
+func SaveLabelMergeRun(prefs fyne.Preferences, run LabelMergeRun) {
+	runs := []LabelMergeRun{run}
+	for _, r := range LoadLabelMergeRuns(prefs) {
+		if r.ID != run.ID && len(runs) < maxLabelMergeRuns {
+			runs = append(runs, r)
+		}
+	}
+	data, err := json.Marshal(runs)
+	if err != nil {
+		return
+	}
+	prefs.SetString("label_merge_runs", string(data))
+}
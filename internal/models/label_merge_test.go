@@ -0,0 +1,191 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func TestNormalizeLabel(t *testing.T) {
+	tests := map[string]string{
+		"Frontend":      "frontend",
+		"front-end":     "frontend",
+		"Front_End":     "frontend",
+		"release/1.2":   "release12",
+		"Tech-Debt.old": "techdebtold",
+		"":              "",
+	}
+	for in, want := range tests {
+		if got := normalizeLabel(in); got != want {
+			t.Errorf("normalizeLabel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestLabelsSimilar(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"frontend", "frontend", true},
+		{"frontend", "frontnd", true},
+		{"backend", "backnd", true},
+		{"documentation", "documnetation", true},
+		{"bug", "bag", false},
+		{"login", "logon", true},
+		{"login", "lgn", false},
+		{"sprint41", "sprint42", false},
+		{"release202401", "release202402", false},
+		{"v1", "v2", false},
+		{"frontend", "backend", false},
+	}
+	for _, tt := range tests {
+		if got := labelsSimilar(tt.a, tt.b); got != tt.want {
+			t.Errorf("labelsSimilar(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSimilarLabels(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts map[string]int
+		want   [][]string
+	}{
+		{
+			name:   "case and separators",
+			counts: map[string]int{"frontend": 10, "Frontend": 3, "front-end": 1, "backend": 5},
+			want:   [][]string{{"frontend", "Frontend", "front-end"}},
+		},
+		{
+			name: "numbered series stay apart",
+			counts: map[string]int{
+				"sprint-41": 4, "sprint-42": 4, "sprint-43": 4,
+				"release-2024-01": 2, "release-2024-02": 2, "release-2024-12": 2,
+			},
+			want: nil,
+		},
+		{
+			name: "only labels close to the anchor",
+			// "abcdef" is one edit from "abcdeg", which is one edit from "abcdhg",
+			// but "abcdhg" is two edits from the anchor
+			counts: map[string]int{"abcdef": 5, "abcdeg": 3, "abcdhg": 2},
+			want:   [][]string{{"abcdef", "abcdeg"}},
+		},
+		{
+			name:   "groups sorted by usage",
+			counts: map[string]int{"backend": 2, "back-end": 1, "frontend": 4, "frontnd": 3},
+			want:   [][]string{{"frontend", "frontnd"}, {"backend", "back-end"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SimilarLabels(tt.counts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SimilarLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanLabelMerge(t *testing.T) {
+	issues := []LabelIssue{
+		{Key: "ABC-1", Labels: []string{"front-end", "ui"}},
+		{Key: "ABC-2", Labels: []string{"frontend", "Frontend"}},
+		{Key: "ABC-3", Labels: []string{"frontend"}},
+		{Key: "ABC-4", Labels: []string{"Frontend", "front-end"}},
+	}
+	run := PlanLabelMerge("ABC", []string{"front-end", "Frontend"}, "frontend", issues)
+	want := []LabelMergeEntry{
+		{Key: "ABC-1", Removed: []string{"front-end"}, Added: true},
+		{Key: "ABC-2", Removed: []string{"Frontend"}, Added: false},
+		{Key: "ABC-4", Removed: []string{"Frontend", "front-end"}, Added: true},
+	}
+	if run.Project != "ABC" || run.Target != "frontend" {
+		t.Errorf("run = %+v", run)
+	}
+	if !reflect.DeepEqual(run.Entries, want) {
+		t.Errorf("entries = %+v, want %+v", run.Entries, want)
+	}
+}
+
+// fakeLabels keeps the labels of issues in memory.
+type fakeLabels struct {
+	mu     sync.Mutex
+	labels map[string][]string
+	fail   map[string]bool
+}
+
+func (f *fakeLabels) update(key string, add, remove []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail[key] {
+		return errors.New("failed")
+	}
+	var labels []string
+	for _, l := range f.labels[key] {
+		removed := false
+		for _, r := range remove {
+			removed = removed || l == r
+		}
+		if !removed {
+			labels = append(labels, l)
+		}
+	}
+	labels = append(labels, add...)
+	sort.Strings(labels)
+	f.labels[key] = labels
+	return nil
+}
+
+func TestLabelMergeUndo(t *testing.T) {
+	prefs := test.NewTempApp(t).Preferences()
+	issues := []LabelIssue{
+		{Key: "ABC-1", Labels: []string{"front-end", "ui"}},
+		{Key: "ABC-2", Labels: []string{"Frontend", "frontend"}},
+		{Key: "ABC-3", Labels: []string{"front-end"}},
+	}
+	fake := &fakeLabels{labels: map[string][]string{}, fail: map[string]bool{"ABC-3": true}}
+	for _, iss := range issues {
+		fake.labels[iss.Key] = append([]string(nil), iss.Labels...)
+	}
+	run := PlanLabelMerge("ABC", []string{"front-end", "Frontend"}, "frontend", issues)
+
+	results := applyLabelMerge(prefs, &run, fake.update, nil)
+	if failed := FailedIssues(results); len(failed) != 1 || failed[0].Key != "ABC-3" {
+		t.Fatalf("failed = %+v", failed)
+	}
+	merged := map[string][]string{
+		"ABC-1": {"frontend", "ui"},
+		"ABC-2": {"frontend"},
+		"ABC-3": {"front-end"},
+	}
+	if !reflect.DeepEqual(fake.labels, merged) {
+		t.Errorf("after merge = %v, want %v", fake.labels, merged)
+	}
+	if done, _ := run.Progress(); done != 2 {
+		t.Errorf("done = %d, want 2", done)
+	}
+	if saved := LoadLabelMergeRuns(prefs); len(saved) != 1 || !reflect.DeepEqual(saved[0].Entries, run.Entries) {
+		t.Errorf("saved run = %+v, want %+v", saved, run)
+	}
+
+	// the failed issue is resumed, then everything is undone
+	delete(fake.fail, "ABC-3")
+	applyLabelMerge(prefs, &run, fake.update, nil)
+	undoLabelMerge(prefs, &run, fake.update, nil)
+	restored := map[string][]string{
+		"ABC-1": {"front-end", "ui"},
+		"ABC-2": {"Frontend", "frontend"},
+		"ABC-3": {"front-end"},
+	}
+	if !reflect.DeepEqual(fake.labels, restored) {
+		t.Errorf("after undo = %v, want %v", fake.labels, restored)
+	}
+	if done, undone := run.Progress(); done != 0 || undone != 3 {
+		t.Errorf("progress = %d/%d, want 0/3", done, undone)
+	}
+}
@@ -0,0 +1,179 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+)
+
+// ErrScanStopped is returned when a label scan was stopped by its progress callback.
+var ErrScanStopped = errors.New("label scan stopped")
+
+// labelScanPageSize is the page size of the project scan, the maximum of /search/jql.
+const labelScanPageSize = 100
+
+// LabelCache holds the labels found in a project with the number of issues using them.
+type LabelCache struct {
+	Counts  map[string]int `json:"counts"`
+	Scanned time.Time      `json:"scanned"`
+}
+
+// FetchAllLabels returns all labels of the Jira site via the label API.
+func FetchAllLabels(domain, email, token string) ([]string, error) {
+	var labels []string
+	startAt := 0
+	for {
+		var page struct {
+			IsLast bool     `json:"isLast"`
+			Values []string `json:"values"`
+		}
+		url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/label?startAt=%d&maxResults=1000", domain, startAt)
+		if err := jiraCall("GET", url, email, token, nil, &page); err != nil {
+			return nil, err
+		}
+		labels = append(labels, page.Values...)
+		startAt += len(page.Values)
+		if page.IsLast || len(page.Values) == 0 {
+			sort.Strings(labels)
+			return labels, nil
+		}
+	}
+}
+
+// CountProjectIssues returns the approximate number of issues in a project.
+func CountProjectIssues(domain, email, token, projectKey string) (int, error) {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/search/approximate-count", domain)
+	var out struct {
+		Count int `json:"count"`
+	}
+	if err := jiraCall("POST", url, email, token, map[string]string{"jql": projectJQL(projectKey)}, &out); err != nil {
+		return 0, err
+	}
+	return out.Count, nil
+}
+
+// ScanProjectLabels pages through all issues of a project and counts how many use each
+// label. progress (may be nil) is called after every page with the scanned and the
+// approximate total number of issues, 0 if it is unknown; returning false stops the
+// scan with ErrScanStopped.
+func ScanProjectLabels(domain, email, token, projectKey string, progress func(scanned, total int) bool) (map[string]int, error) {
+	total, err := CountProjectIssues(domain, email, token, projectKey)
+	if err != nil {
+		// the count is only used for the progress, which is then reported without a total
+		fmt.Println("Error counting issues:", err)
+		total = 0
+	}
+
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/search/jql", domain)
+	counts := map[string]int{}
+	scanned := 0
+	nextPageToken := ""
+	for {
+		body := map[string]interface{}{
+			"jql":        projectJQL(projectKey),
+			"fields":     []string{"labels"},
+			"maxResults": labelScanPageSize,
+		}
+		if nextPageToken != "" {
+			body["nextPageToken"] = nextPageToken
+		}
+		var page struct {
+			Issues []struct {
+				Fields struct {
+					Labels []string `json:"labels"`
+				} `json:"fields"`
+			} `json:"issues"`
+			NextPageToken string `json:"nextPageToken"`
+			IsLast        bool   `json:"isLast"`
+		}
+		if err := jiraCall("POST", url, email, token, body, &page); err != nil {
+			return nil, err
+		}
+
+		for _, iss := range page.Issues {
+			for _, l := range iss.Fields.Labels {
+				if l != "" {
+					counts[l]++
+				}
+			}
+		}
+		scanned += len(page.Issues)
+		if total > 0 && scanned > total {
+			total = scanned
+		}
+		if progress != nil && !progress(scanned, total) {
+			return counts, ErrScanStopped
+		}
+		if page.IsLast || page.NextPageToken == "" || len(page.Issues) == 0 {
+			return counts, nil
+		}
+		nextPageToken = page.NextPageToken
+	}
+}
+
+func projectJQL(projectKey string) string {
+	return fmt.Sprintf(`project = "%s"`, projectKey)
+}
+
+// SortedLabels returns the labels of a count map in alphabetical order.
+func SortedLabels(counts map[string]int) []string {
+	labels := make([]string, 0, len(counts))
+	for l := range counts {
+		labels = append(labels, l)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// LoadLabelCache returns the result of the last scan of the project.
+func LoadLabelCache(prefs fyne.Preferences, projectKey string) (LabelCache, bool) {
+	var cache LabelCache
+	raw := prefs.String("label_cache_" + projectKey)
+	if raw == "" {
+		return cache, false
+	}
+	if err := json.Unmarshal([]byte(raw), &cache); err != nil || cache.Counts == nil {
+		return LabelCache{}, false
+	}
+	return cache, true
+}
+
+// SaveLabelCache stores the result of a project scan.
+func SaveLabelCache(prefs fyne.Preferences, projectKey string, counts map[string]int) {
+	data, err := json.Marshal(LabelCache{Counts: counts, Scanned: time.Now()})
+	if err != nil {
+		return
+	}
+	prefs.SetString("label_cache_"+projectKey, string(data))
+}
+
+// MaxLabelLength is the longest label Jira accepts.
+const MaxLabelLength = 255
+
+// ValidateLabel errors, the UI shows its own text for them.
+var (
+	ErrLabelWhitespace = errors.New("labels can't contain spaces")
+	ErrLabelTooLong    = fmt.Errorf("labels can't be longer than %d characters", MaxLabelLength)
+)
+
+// ValidateLabel checks a new label against Jira's rules: no whitespace and at most 255 characters.
+func ValidateLabel(label string) error {
+	if strings.ContainsAny(label, " \t\n\r") {
+		return ErrLabelWhitespace
+	}
+	if utf8.RuneCountInString(label) > MaxLabelLength {
+		return ErrLabelTooLong
+	}
+	return nil
+}
+
+// ClearLabelCache drops the scan result of the project, e.g. after its labels were changed.
+func ClearLabelCache(prefs fyne.Preferences, projectKey string) {
+	prefs.RemoveValue("label_cache_" + projectKey)
+}
@@ -0,0 +1,275 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+type JiraPriority struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	IconURL string `json:"iconUrl"`
+}
+
+type JiraStatusCategory struct {
+	ID        int    `json:"id"`
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	ColorName string `json:"colorName"`
+}
+
+type JiraStatus struct {
+	ID             string             `json:"id"`
+	Name           string             `json:"name"`
+	StatusCategory JiraStatusCategory `json:"statusCategory"`
+}
+
+// JiraMetadata holds the site-wide issue type, status and priority definitions.
+type JiraMetadata struct {
+	IssueTypes map[string]JiraIssueType
+	Statuses   map[string]JiraStatus
+	Priorities map[string]JiraPriority
+}
+
+var (
+	metadataMu    sync.Mutex
+	metadataCache = map[string]*JiraMetadata{}
+
+	iconMu    sync.Mutex
+	iconCache = map[string]*iconEntry{}
+)
+
+// maxIconSize limits the bytes read of an icon download.
+const maxIconSize = 1 << 20
+
+// iconRetryAfter is how long a failed icon download is not retried.
+const iconRetryAfter = 5 * time.Minute
+
+// iconEntry is the download state of an icon.
+type iconEntry struct {
+	res      fyne.Resource
+	loading  bool
+	failedAt time.Time
+	// waiting is called once the icon is available
+	waiting []func()
+}
+
+// FetchPriorities returns all priorities configured on the Jira site.
+func FetchPriorities(domain, email, token string) ([]JiraPriority, error) {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/priority", domain)
+	var out []JiraPriority
+	if err := jiraCall("GET", url, email, token, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FetchIssueTypes returns all issue types visible to the user, including their icons.
+func FetchIssueTypes(domain, email, token string) ([]JiraIssueType, error) {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issuetype", domain)
+	var out []JiraIssueType
+	if err := jiraCall("GET", url, email, token, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FetchStatuses returns all workflow statuses with their status category.
+func FetchStatuses(domain, email, token string) ([]JiraStatus, error) {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/status", domain)
+	var out []JiraStatus
+	if err := jiraCall("GET", url, email, token, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LoadJiraMetadata loads issue types, statuses and priorities once per site and caches them.
+// Pass refresh=true to drop the cached copy, e.g. after the Jira admin changed the scheme.
+func LoadJiraMetadata(domain, email, token string, refresh bool) (*JiraMetadata, error) {
+	metadataMu.Lock()
+	cached, ok := metadataCache[domain]
+	metadataMu.Unlock()
+	if ok && !refresh {
+		return cached, nil
+	}
+
+	types, err := FetchIssueTypes(domain, email, token)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := FetchStatuses(domain, email, token)
+	if err != nil {
+		return nil, err
+	}
+	priorities, err := FetchPriorities(domain, email, token)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &JiraMetadata{
+		IssueTypes: map[string]JiraIssueType{},
+		Statuses:   map[string]JiraStatus{},
+		Priorities: map[string]JiraPriority{},
+	}
+	for _, t := range types {
+		meta.IssueTypes[t.ID] = t
+	}
+	for _, s := range statuses {
+		meta.Statuses[s.ID] = s
+	}
+	for _, p := range priorities {
+		meta.Priorities[p.ID] = p
+	}
+
+	metadataMu.Lock()
+	metadataCache[domain] = meta
+	metadataMu.Unlock()
+	return meta, nil
+}
+
+// CachedJiraMetadata returns the metadata of a site if it has been loaded already.
+func CachedJiraMetadata(domain string) *JiraMetadata {
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+	return metadataCache[domain]
+}
+
+// IssueType resolves the issue type of an issue, preferring the site definition.
+func (m *JiraMetadata) IssueType(t JiraIssueType) JiraIssueType {
+	if m != nil {
+		if def, ok := m.IssueTypes[t.ID]; ok {
+			return def
+		}
+	}
+	return t
+}
+
+// Status resolves the status of an issue, preferring the site definition.
+func (m *JiraMetadata) Status(s JiraStatus) JiraStatus {
+	if m != nil {
+		if def, ok := m.Statuses[s.ID]; ok {
+			return def
+		}
+	}
+	return s
+}
+
+// Priority resolves the priority of an issue, preferring the site definition.
+func (m *JiraMetadata) Priority(p JiraPriority) JiraPriority {
+	if m != nil {
+		if def, ok := m.Priorities[p.ID]; ok {
+			return def
+		}
+	}
+	return p
+}
+
+// CachedIcon returns the icon behind iconURL if it has been downloaded already.
+// Otherwise it starts the download in the background and calls onLoaded once the
+// icon is available, so callers can simply refresh their widgets. Every call with
+// onLoaded registers it again, so callers that ask often register one per URL.
+// Failed downloads are retried after iconRetryAfter at the earliest.
+func CachedIcon(domain, email, token, iconURL string, onLoaded func()) fyne.Resource {
+	if iconURL == "" {
+		return nil
+	}
+
+	iconMu.Lock()
+	entry, ok := iconCache[iconURL]
+	if !ok {
+		entry = &iconEntry{}
+		iconCache[iconURL] = entry
+	}
+	if entry.res != nil {
+		iconMu.Unlock()
+		return entry.res
+	}
+	if onLoaded != nil {
+		entry.waiting = append(entry.waiting, onLoaded)
+	}
+	start := !entry.loading && time.Since(entry.failedAt) >= iconRetryAfter
+	if start {
+		entry.loading = true
+	}
+	iconMu.Unlock()
+
+	if !start {
+		return nil
+	}
+
+	go func() {
+		res, err := fetchIcon(domain, email, token, iconURL)
+		if err != nil {
+			fmt.Println("Error loading icon:", err)
+		}
+
+		iconMu.Lock()
+		entry.loading = false
+		if res == nil {
+			// keep the callbacks for the next attempt
+			entry.failedAt = time.Now()
+			iconMu.Unlock()
+			return
+		}
+		entry.res = res
+		callbacks := entry.waiting
+		entry.waiting = nil
+		iconMu.Unlock()
+
+		for _, cb := range callbacks {
+			cb()
+		}
+	}()
+	return nil
+}
+
+// fetchIcon downloads an icon. Avatar URLs of the Jira site require authentication,
+// so we can't use fyne.LoadResourceFromURLString here; icons on other hosts are
+// fetched without the credentials.
+func fetchIcon(domain, email, token, iconURL string) (fyne.Resource, error) {
+	u, err := url.Parse(iconURL)
+	if err != nil {
+		return nil, err
+	}
+	var req *http.Request
+	if strings.EqualFold(u.Hostname(), domain+".atlassian.net") {
+		req, err = newJiraRequest("GET", iconURL, email, token, nil)
+	} else {
+		req, err = http.NewRequest("GET", iconURL, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "image/svg+xml,image/png,image/*")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("icon download failed (%d): %s", res.StatusCode, iconURL)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, maxIconSize))
+	if err != nil {
+		return nil, err
+	}
+
+	// Fyne detects SVG by file extension, so derive a name that matches the content.
+	name := path.Base(strings.SplitN(iconURL, "?", 2)[0])
+	if strings.Contains(res.Header.Get("Content-Type"), "svg") && !strings.HasSuffix(name, ".svg") {
+		name += ".svg"
+	}
+	return fyne.NewStaticResource(name, data), nil
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// epicLinkSchema is the custom field type of "Epic Link" in older company-managed projects.
+const epicLinkSchema = "com.pyxis.greenhopper.jira:gh-epic-link"
+
+// IssuePickerResult is an issue suggested by the issue picker.
+type IssuePickerResult struct {
+	Key     string `json:"key"`
+	Summary string `json:"summaryText"`
+}
+
+type issuePickerResponse struct {
+	Sections []struct {
+		ID     string              `json:"id"`
+		Issues []IssuePickerResult `json:"issues"`
+	} `json:"sections"`
+}
+
+// SearchIssuePicker returns issues matching the query as typed by the user.
+// jql restricts the suggestions (may be empty).
+func SearchIssuePicker(domain, email, token, query, jql string) ([]IssuePickerResult, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("showSubTasks", "false")
+	if jql != "" {
+		params.Set("currentJQL", jql)
+	}
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/picker?%s", domain, params.Encode())
+
+	var data issuePickerResponse
+	if err := jiraCall("GET", u, email, token, nil, &data); err != nil {
+		return nil, err
+	}
+
+	// the same issue can show up in the history and the current search section
+	seen := map[string]bool{}
+	var out []IssuePickerResult
+	for _, section := range data.Sections {
+		for _, iss := range section.Issues {
+			if seen[iss.Key] {
+				continue
+			}
+			seen[iss.Key] = true
+			out = append(out, iss)
+		}
+	}
+	return out, nil
+}
+
+// ParentPickerJQL returns the JQL for parent candidates: sub-tasks need a standard
+// issue as parent, other issue types an issue of one of the epic types.
+func ParentPickerJQL(projectKey string, subtask bool, epicTypeIDs []string) string {
+	jql := fmt.Sprintf("project = \"%s\"", projectKey)
+	if subtask {
+		return jql + " AND issuetype in standardIssueTypes()"
+	}
+	return jql + " AND " + epicTypeJQL(epicTypeIDs)
+}
+
+// EpicTypeIDs returns the IDs of the issue types on the epic level of the hierarchy,
+// whatever they are called in the project or language.
+func EpicTypeIDs(types []JiraIssueType) []string {
+	var ids []string
+	for _, t := range types {
+		if t.HierarchyLevel == 1 {
+			ids = append(ids, t.ID)
+		}
+	}
+	return ids
+}
+
+// epicTypeJQL restricts a query to the epic types. Without known types it leaves out
+// standard issue types and sub-tasks instead.
+func epicTypeJQL(epicTypeIDs []string) string {
+	if len(epicTypeIDs) == 0 {
+		return "issuetype not in standardIssueTypes() AND issuetype not in subtaskIssueTypes()"
+	}
+	return fmt.Sprintf("issuetype in (%s)", strings.Join(epicTypeIDs, ", "))
+}
+
+// ParentFields returns the create payload fields linking a new issue to its parent.
+// Sub-tasks and team-managed projects use the parent field, company-managed projects
+// without parent support on the create screen fall back to the Epic Link field.
+func ParentFields(fields []JiraField, parentKey string, subtask bool) map[string]interface{} {
+	parent := map[string]interface{}{"parent": map[string]string{"key": parentKey}}
+	if subtask {
+		return parent
+	}
+	for _, f := range fields {
+		if f.ID() == "parent" {
+			return parent
+		}
+	}
+	for _, f := range fields {
+		if f.Schema.Custom == epicLinkSchema {
+			return map[string]interface{}{f.ID(): parentKey}
+		}
+	}
+	return parent
+}
+
+// ProjectKeyOf returns the project key part of an issue key ("ABC-12" -> "ABC").
+func ProjectKeyOf(issueKey string) string {
+	if i := strings.LastIndex(issueKey, "-"); i > 0 {
+		return issueKey[:i]
+	}
+	return issueKey
+}
@@ -0,0 +1,771 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+)
+
+// PokerDefaultPort is the port a planning poker host listens on if it is free.
+const PokerDefaultPort = 47800
+
+// pokerPollTimeout is how long a poll for changes of the session waits.
+const pokerPollTimeout = 25 * time.Second
+
+// pokerMemberTimeout drops participants that stopped polling, e.g. after a crash.
+const pokerMemberTimeout = 2*pokerPollTimeout + 10*time.Second
+
+// Limits of a session.
+const (
+	maxPokerParticipants = 50
+	// MaxPokerNameLength is the maximum number of characters of a participant's name.
+	MaxPokerNameLength = 40
+	// pokerSecretSize is the number of random bytes of the session secret.
+	pokerSecretSize = 8
+)
+
+// pokerSecretHeader carries the session secret on every request.
+const pokerSecretHeader = "X-Poker-Secret"
+
+// PokerDeck is a named set of cards to vote with.
+type PokerDeck struct {
+	Name  string   `json:"name"`
+	Cards []string `json:"cards"`
+}
+
+// PokerDecks are the built-in card decks.
+var PokerDecks = []PokerDeck{
+	{Name: "Fibonacci", Cards: []string{"0", "1", "2", "3", "5", "8", "13", "21", "?", "☕"}},
+	{Name: "Modified Fibonacci", Cards: []string{"0", "½", "1", "2", "3", "5", "8", "13", "20", "40", "100", "?", "☕"}},
+	{Name: "Powers of 2", Cards: []string{"0", "1", "2", "4", "8", "16", "32", "?", "☕"}},
+	{Name: "T-shirt", Cards: []string{"XS", "S", "M", "L", "XL", "XXL", "?", "☕"}},
+}
+
+// LoadPokerCustomCards returns the cards of the user's own deck, nil if not configured.
+func LoadPokerCustomCards(prefs fyne.Preferences) []string {
+	return ParsePokerCards(prefs.String("poker_custom_deck"))
+}
+
+// SavePokerCustomCards stores the cards of the user's own deck.
+func SavePokerCustomCards(prefs fyne.Preferences, cards []string) {
+	prefs.SetString("poker_custom_deck", strings.Join(cards, ", "))
+}
+
+// ParsePokerCards splits a comma separated list of cards, dropping empty and duplicate ones.
+func ParsePokerCards(text string) []string {
+	var cards []string
+	seen := map[string]bool{}
+	for _, c := range strings.Split(text, ",") {
+		c = strings.TrimSpace(c)
+		if c != "" && !seen[c] {
+			seen[c] = true
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+// PokerCardValue returns the number on a card, ok is false for cards like "?" or "M".
+func PokerCardValue(card string) (value float64, ok bool) {
+	if card == "½" {
+		return 0.5, true
+	}
+	v, err := strconv.ParseFloat(strings.ReplaceAll(card, ",", "."), 64)
+	return v, err == nil
+}
+
+// PokerIssue is an issue to estimate. Estimate is the agreed card, "" until agreed.
+type PokerIssue struct {
+	Key         string `json:"key"`
+	Summary     string `json:"summary"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Estimate    string `json:"estimate"`
+}
+
+// PokerParticipant is a member of a session as seen by one participant. Vote is only
+// filled for the participant's own vote and for all votes once they are revealed.
+type PokerParticipant struct {
+	Name  string `json:"name"`
+	Host  bool   `json:"host"`
+	Voted bool   `json:"voted"`
+	Vote  string `json:"vote"`
+	// You marks the participant the state was made for.
+	You bool `json:"you"`
+}
+
+// PokerState is the state of a session. Version grows with every change.
+type PokerState struct {
+	Version      int                `json:"version"`
+	Deck         PokerDeck          `json:"deck"`
+	Issues       []PokerIssue       `json:"issues"`
+	Current      int                `json:"current"` // index into Issues, -1 if none
+	Revealed     bool               `json:"revealed"`
+	Participants []PokerParticipant `json:"participants"`
+	Closed       bool               `json:"closed"`
+}
+
+// CurrentIssue returns the issue being estimated.
+func (s PokerState) CurrentIssue() (PokerIssue, bool) {
+	if s.Current < 0 || s.Current >= len(s.Issues) {
+		return PokerIssue{}, false
+	}
+	return s.Issues[s.Current], true
+}
+
+// Votes counts the revealed votes per card in deck order and suggests the most frequent
+// card, the higher one on a tie. Cards without a number are only suggested if no
+// numbered card was played.
+func (s PokerState) Votes() (cards []string, counts map[string]int, suggestion string) {
+	counts = map[string]int{}
+	for _, p := range s.Participants {
+		if p.Vote != "" {
+			counts[p.Vote]++
+		}
+	}
+	for _, c := range s.Deck.Cards {
+		if counts[c] > 0 {
+			cards = append(cards, c)
+		}
+	}
+	best := 0
+	for _, c := range cards {
+		_, numbered := PokerCardValue(c)
+		_, bestNumbered := PokerCardValue(suggestion)
+		switch {
+		case suggestion == "", numbered && !bestNumbered, numbered == bestNumbered && counts[c] >= best:
+			suggestion, best = c, counts[c]
+		}
+	}
+	return cards, counts, suggestion
+}
+
+// PokerSession is a planning poker session, hosted by this instance or joined over the
+// network.
+type PokerSession interface {
+	// State returns the state once its version is greater than since, or the current
+	// state after a timeout.
+	State(since int) (PokerState, error)
+	// Vote plays a card, "" takes the vote back.
+	Vote(card string) error
+	// Leave leaves the session; for the host it ends the session.
+	Leave() error
+}
+
+type pokerMember struct {
+	id   string
+	name string
+	host bool
+	vote string
+	seen time.Time
+}
+
+// PokerHost runs a session and serves it to other instances over HTTP.
+type PokerHost struct {
+	mu      sync.Mutex
+	state   PokerState
+	members []*pokerMember
+	hostID  string
+	changed chan struct{}
+
+	secret   string
+	server   *http.Server
+	listener net.Listener
+	stop     chan struct{}
+}
+
+var _ PokerSession = (*PokerHost)(nil)
+
+// NewPokerHost starts a session on the given local address and port, or on a free port of
+// the address if the port is in use. Only requests presenting the session secret, which
+// is part of the join code, are served.
+func NewPokerHost(name string, deck PokerDeck, ip net.IP, port int) (*PokerHost, error) {
+	if ip.To4() == nil {
+		return nil, errors.New("no local network address selected")
+	}
+	host := ip.To4().String()
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		if listener, err = net.Listen("tcp", net.JoinHostPort(host, "0")); err != nil {
+			return nil, err
+		}
+	}
+	secret := make([]byte, pokerSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	h := &PokerHost{
+		state:    PokerState{Version: 1, Deck: deck, Current: -1},
+		hostID:   newPokerID(),
+		changed:  make(chan struct{}),
+		secret:   pokerCodeEncoding.EncodeToString(secret),
+		listener: listener,
+		stop:     make(chan struct{}),
+	}
+	h.members = append(h.members, &pokerMember{id: h.hostID, name: name, host: true})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /join", h.handleJoin)
+	mux.HandleFunc("GET /state", h.handleState)
+	mux.HandleFunc("POST /vote", h.handleVote)
+	mux.HandleFunc("POST /leave", h.handleLeave)
+	h.server = &http.Server{Handler: h.requireSecret(mux), ReadHeaderTimeout: 10 * time.Second}
+	go h.server.Serve(listener)
+	go h.dropSilentMembers()
+	return h, nil
+}
+
+func newPokerID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// requireSecret rejects requests that do not present the session secret.
+func (h *PokerHost) requireSecret(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(pokerSecretHeader)), []byte(h.secret)) != 1 {
+			http.Error(w, "invalid session secret", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Address returns the "ip:port" address the session is served on.
+func (h *PokerHost) Address() string {
+	return h.listener.Addr().String()
+}
+
+// Code returns the code other instances join with. It contains address, port and secret.
+func (h *PokerHost) Code() string {
+	addr := h.listener.Addr().(*net.TCPAddr)
+	secret, _ := pokerCodeEncoding.DecodeString(h.secret)
+	b := make([]byte, 6, 6+len(secret))
+	copy(b, addr.IP.To4())
+	binary.BigEndian.PutUint16(b[4:], uint16(addr.Port))
+	code := pokerCodeEncoding.EncodeToString(append(b, secret...))
+	var groups []string
+	for len(code) > 5 {
+		groups, code = append(groups, code[:5]), code[5:]
+	}
+	return strings.Join(append(groups, code), "-")
+}
+
+// Secret returns the session secret, needed besides the address to join without code.
+func (h *PokerHost) Secret() string {
+	return h.secret
+}
+
+// PokerLANAddresses returns the IPv4 addresses of this machine a session can be hosted on.
+func PokerLANAddresses() []net.IP {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil
+	}
+	var ips []net.IP
+	for _, a := range addrs {
+		if n, ok := a.(*net.IPNet); ok && !n.IP.IsLoopback() && n.IP.To4() != nil {
+			ips = append(ips, n.IP.To4())
+		}
+	}
+	return ips
+}
+
+var pokerCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// pokerTarget turns a join code, or an address with optional port followed by
+// "/secret", into "host:port" and the session secret.
+func pokerTarget(input string) (address, secret string, err error) {
+	input = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(input), "http://"))
+	if input == "" {
+		return "", "", errors.New("no session code")
+	}
+	if !strings.ContainsAny(input, ".:/") {
+		code := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(input))
+		b, err := pokerCodeEncoding.DecodeString(code)
+		if err != nil || len(b) != 6+pokerSecretSize {
+			return "", "", fmt.Errorf("invalid session code %q", input)
+		}
+		address = net.JoinHostPort(net.IP(b[:4]).String(), strconv.Itoa(int(binary.BigEndian.Uint16(b[4:6]))))
+		return address, pokerCodeEncoding.EncodeToString(b[6:]), nil
+	}
+	address, secret, found := strings.Cut(input, "/")
+	if !found || strings.TrimSpace(secret) == "" {
+		return "", "", errors.New("the address must be followed by /secret of the session")
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, strconv.Itoa(PokerDefaultPort))
+	}
+	return address, strings.ToUpper(strings.TrimSpace(secret)), nil
+}
+
+// changedLocked publishes a change to everyone waiting for one. h.mu must be held.
+func (h *PokerHost) changedLocked() {
+	h.state.Version++
+	close(h.changed)
+	h.changed = make(chan struct{})
+}
+
+// viewLocked is the state as seen by the member with the ID. h.mu must be held.
+func (h *PokerHost) viewLocked(id string) PokerState {
+	s := h.state
+	s.Issues = append([]PokerIssue(nil), h.state.Issues...)
+	s.Participants = nil
+	for _, m := range h.members {
+		p := PokerParticipant{Name: m.name, Host: m.host, Voted: m.vote != "", You: m.id == id}
+		if s.Revealed || p.You {
+			p.Vote = m.vote
+		}
+		s.Participants = append(s.Participants, p)
+	}
+	return s
+}
+
+func (h *PokerHost) member(id string) *pokerMember {
+	for _, m := range h.members {
+		if m.id == id {
+			return m
+		}
+	}
+	return nil
+}
+
+// wait returns the state for the member once it is newer than since or after the timeout.
+func (h *PokerHost) wait(id string, since int, timeout time.Duration) (PokerState, error) {
+	h.mu.Lock()
+	m := h.member(id)
+	if m == nil {
+		h.mu.Unlock()
+		return PokerState{}, errors.New("not a member of the session")
+	}
+	m.seen = time.Now()
+	if h.state.Version > since || h.state.Closed {
+		defer h.mu.Unlock()
+		return h.viewLocked(id), nil
+	}
+	changed := h.changed
+	h.mu.Unlock()
+
+	select {
+	case <-changed:
+	case <-time.After(timeout):
+	case <-h.stop:
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if m := h.member(id); m != nil {
+		m.seen = time.Now()
+	}
+	return h.viewLocked(id), nil
+}
+
+func (h *PokerHost) vote(id, card string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	m := h.member(id)
+	if m == nil {
+		return errors.New("not a member of the session")
+	}
+	if h.state.Current < 0 {
+		return errors.New("no issue is being estimated")
+	}
+	if h.state.Revealed {
+		return errors.New("the votes have already been revealed")
+	}
+	if card != "" && !containsString(h.state.Deck.Cards, card) {
+		return fmt.Errorf("%q is not a card of the deck", card)
+	}
+	m.vote = card
+	h.changedLocked()
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// dropSilentMembers removes participants that stopped polling.
+func (h *PokerHost) dropSilentMembers() {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.stop:
+			return
+		case <-ticker.C:
+		}
+		h.mu.Lock()
+		kept := h.members[:0]
+		for _, m := range h.members {
+			if m.host || time.Since(m.seen) < pokerMemberTimeout {
+				kept = append(kept, m)
+			}
+		}
+		if len(kept) != len(h.members) {
+			h.members = kept
+			h.changedLocked()
+		}
+		h.mu.Unlock()
+	}
+}
+
+// State implements PokerSession for the host.
+func (h *PokerHost) State(since int) (PokerState, error) {
+	return h.wait(h.hostID, since, pokerPollTimeout)
+}
+
+// Vote implements PokerSession for the host.
+func (h *PokerHost) Vote(card string) error {
+	return h.vote(h.hostID, card)
+}
+
+// Leave ends the session: participants are told it is closed and the server stops.
+func (h *PokerHost) Leave() error {
+	h.mu.Lock()
+	if h.state.Closed {
+		h.mu.Unlock()
+		return nil
+	}
+	h.state.Closed = true
+	h.changedLocked()
+	h.mu.Unlock()
+	close(h.stop)
+
+	// give waiting participants a moment to receive the closed state
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return h.server.Shutdown(ctx)
+}
+
+// AddIssues appends issues that are not in the session yet.
+func (h *PokerHost) AddIssues(issues []PokerIssue) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, issue := range issues {
+		exists := false
+		for _, s := range h.state.Issues {
+			exists = exists || s.Key == issue.Key
+		}
+		if !exists {
+			h.state.Issues = append(h.state.Issues, issue)
+		}
+	}
+	h.changedLocked()
+}
+
+// RemoveIssue removes an issue from the session.
+func (h *PokerHost) RemoveIssue(key string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	index := h.indexLocked(key)
+	if index < 0 {
+		return
+	}
+	h.state.Issues = append(h.state.Issues[:index], h.state.Issues[index+1:]...)
+	switch {
+	case h.state.Current == index:
+		h.state.Current = -1
+		h.clearVotesLocked()
+	case h.state.Current > index:
+		h.state.Current--
+	}
+	h.changedLocked()
+}
+
+// Select starts the vote on an issue of the session.
+func (h *PokerHost) Select(key string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	index := h.indexLocked(key)
+	if index < 0 {
+		return
+	}
+	h.state.Current = index
+	h.clearVotesLocked()
+	h.changedLocked()
+}
+
+func (h *PokerHost) indexLocked(key string) int {
+	for i, issue := range h.state.Issues {
+		if issue.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetDescription fills in the description of an issue once it is loaded.
+func (h *PokerHost) SetDescription(key, description string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i := range h.state.Issues {
+		if h.state.Issues[i].Key == key {
+			h.state.Issues[i].Description = description
+		}
+	}
+	h.changedLocked()
+}
+
+// Reveal shows all votes of the current issue.
+func (h *PokerHost) Reveal() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.state.Revealed = true
+	h.changedLocked()
+}
+
+// Revote clears the votes of the current issue for another round.
+func (h *PokerHost) Revote() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clearVotesLocked()
+	h.changedLocked()
+}
+
+func (h *PokerHost) clearVotesLocked() {
+	h.state.Revealed = false
+	for _, m := range h.members {
+		m.vote = ""
+	}
+}
+
+// SetEstimate records the agreed card of an issue.
+func (h *PokerHost) SetEstimate(key, card string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i := range h.state.Issues {
+		if h.state.Issues[i].Key == key {
+			h.state.Issues[i].Estimate = card
+		}
+	}
+	h.changedLocked()
+}
+
+type pokerRequest struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Card string `json:"card"`
+}
+
+type pokerJoinResponse struct {
+	ID    string     `json:"id"`
+	State PokerState `json:"state"`
+}
+
+func readPokerRequest(w http.ResponseWriter, r *http.Request) (pokerRequest, bool) {
+	var req pokerRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<12)).Decode(&req); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
+
+func writePokerJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func (h *PokerHost) handleJoin(w http.ResponseWriter, r *http.Request) {
+	req, ok := readPokerRequest(w, r)
+	if !ok {
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		http.Error(w, "a name is required", http.StatusBadRequest)
+		return
+	}
+	if utf8.RuneCountInString(name) > MaxPokerNameLength {
+		http.Error(w, fmt.Sprintf("the name is longer than %d characters", MaxPokerNameLength), http.StatusBadRequest)
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.state.Closed {
+		http.Error(w, "the session has ended", http.StatusGone)
+		return
+	}
+	if len(h.members) >= maxPokerParticipants {
+		http.Error(w, "the session is full", http.StatusServiceUnavailable)
+		return
+	}
+	// names identify participants for everyone else, so they must be unique
+	names := map[string]bool{}
+	for _, m := range h.members {
+		names[m.name] = true
+	}
+	unique := name
+	for i := 2; names[unique]; i++ {
+		unique = fmt.Sprintf("%s (%d)", name, i)
+	}
+	m := &pokerMember{id: newPokerID(), name: unique, seen: time.Now()}
+	h.members = append(h.members, m)
+	h.changedLocked()
+	writePokerJSON(w, pokerJoinResponse{ID: m.id, State: h.viewLocked(m.id)})
+}
+
+func (h *PokerHost) handleState(w http.ResponseWriter, r *http.Request) {
+	since, _ := strconv.Atoi(r.URL.Query().Get("since"))
+	state, err := h.wait(r.URL.Query().Get("id"), since, pokerPollTimeout)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writePokerJSON(w, state)
+}
+
+func (h *PokerHost) handleVote(w http.ResponseWriter, r *http.Request) {
+	req, ok := readPokerRequest(w, r)
+	if !ok {
+		return
+	}
+	if err := h.vote(req.ID, req.Card); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PokerHost) handleLeave(w http.ResponseWriter, r *http.Request) {
+	req, ok := readPokerRequest(w, r)
+	if !ok {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for i, m := range h.members {
+		if m.id == req.ID && !m.host {
+			h.members = append(h.members[:i], h.members[i+1:]...)
+			h.changedLocked()
+			break
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PokerClient is a session joined on another instance.
+type PokerClient struct {
+	base   string
+	secret string
+	id     string
+	client *http.Client
+	// initial is the state returned on joining, handed out by the first State call.
+	initial *PokerState
+}
+
+var _ PokerSession = (*PokerClient)(nil)
+
+// JoinPokerSession joins the session with a join code or "host[:port]/secret".
+func JoinPokerSession(codeOrAddress, name string) (*PokerClient, error) {
+	address, secret, err := pokerTarget(codeOrAddress)
+	if err != nil {
+		return nil, err
+	}
+	c := &PokerClient{
+		base:   "http://" + address,
+		secret: secret,
+		client: &http.Client{Timeout: pokerPollTimeout + 10*time.Second},
+	}
+	var joined pokerJoinResponse
+	if err := c.call("POST", "/join", pokerRequest{Name: name}, &joined); err != nil {
+		return nil, err
+	}
+	c.id, c.initial = joined.ID, &joined.State
+	return c, nil
+}
+
+func (c *PokerClient) call(method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.base+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(pokerSecretHeader, c.secret)
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("poker host error (%d): %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// State implements PokerSession.
+func (c *PokerClient) State(since int) (PokerState, error) {
+	if c.initial != nil && c.initial.Version > since {
+		state := *c.initial
+		c.initial = nil
+		return state, nil
+	}
+	var state PokerState
+	q := url.Values{"id": {c.id}, "since": {strconv.Itoa(since)}}
+	err := c.call("GET", "/state?"+q.Encode(), nil, &state)
+	return state, err
+}
+
+// Vote implements PokerSession.
+func (c *PokerClient) Vote(card string) error {
+	return c.call("POST", "/vote", pokerRequest{ID: c.id, Card: card}, nil)
+}
+
+// Leave implements PokerSession.
+func (c *PokerClient) Leave() error {
+	return c.call("POST", "/leave", pokerRequest{ID: c.id}, nil)
+}
+
+// PokerIssuesOf converts board issues for a session, leaving out sub-tasks. Their
+// current estimate is shown as agreed estimate.
+func PokerIssuesOf(issues []BoardIssue) []PokerIssue {
+	var out []PokerIssue
+	for _, issue := range issues {
+		if issue.IssueType.Subtask {
+			continue
+		}
+		p := PokerIssue{Key: issue.Key, Summary: issue.Summary, Type: issue.IssueType.Name}
+		if issue.Estimate > 0 {
+			p.Estimate = strconv.FormatFloat(issue.Estimate, 'f', -1, 64)
+		}
+		out = append(out, p)
+	}
+	return out
+}
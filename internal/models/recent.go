@@ -0,0 +1,41 @@
+package models
+
+import (
+	"encoding/json"
+
+	"fyne.io/fyne/v2"
+)
+
+const maxRecentIssues = 15
+
+// RecentIssue is an issue the user opened recently, shown in the command palette.
+type RecentIssue struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+}
+
+// LoadRecentIssues returns the recently opened issues, newest first.
+func LoadRecentIssues(prefs fyne.Preferences) []RecentIssue {
+	var recent []RecentIssue
+	if raw := prefs.String("recent_issues"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &recent); err != nil {
+			return nil
+		}
+	}
+	return recent
+}
+
+// AddRecentIssue moves the issue to the top of the recent issues list.
+func AddRecentIssue(prefs fyne.Preferences, issue JiraIssue) {
+	recent := []RecentIssue{{Key: issue.Key, Summary: issue.Fields.Summary}}
+	for _, r := range LoadRecentIssues(prefs) {
+		if r.Key != issue.Key && len(recent) < maxRecentIssues {
+			recent = append(recent, r)
+		}
+	}
+	data, err := json.Marshal(recent)
+	if err != nil {
+		return
+	}
+	prefs.SetString("recent_issues", string(data))
+}
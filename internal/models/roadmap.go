@@ -0,0 +1,269 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRoadmapEpics limits the epics loaded for the roadmap.
+const maxRoadmapEpics = 200
+
+// roadmapChildBatch is the number of epics whose children are searched at once.
+const roadmapChildBatch = 50
+
+// maxRoadmapChildren limits the child issues loaded per batch of epics.
+const maxRoadmapChildren = 5000
+
+// jiraDate is the format of Jira date fields like the due date.
+const jiraDate = "2006-01-02"
+
+// RoadmapFields are the IDs of the site's custom fields the roadmap uses, "" if missing.
+type RoadmapFields struct {
+	StartDate string
+	Sprint    string
+}
+
+// FetchRoadmapFields looks up the "Start date" and "Sprint" custom fields of the site.
+func FetchRoadmapFields(domain, email, token string) (RoadmapFields, error) {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/field", domain)
+	var fields []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Schema struct {
+			Type   string `json:"type"`
+			Custom string `json:"custom"`
+		} `json:"schema"`
+	}
+	if err := jiraCall("GET", u, email, token, nil, &fields); err != nil {
+		return RoadmapFields{}, err
+	}
+	var out RoadmapFields
+	for _, f := range fields {
+		switch {
+		case f.Schema.Custom == "com.pyxis.greenhopper.jira:gh-sprint" && out.Sprint == "":
+			out.Sprint = f.ID
+		case f.Schema.Type == "date" && strings.EqualFold(f.Name, "Start date") && out.StartDate == "":
+			out.StartDate = f.ID
+		}
+	}
+	return out, nil
+}
+
+// RoadmapEpic is an epic with its dates and the progress of its children.
+type RoadmapEpic struct {
+	Key     string
+	Summary string
+	Project string
+	Status  JiraStatus
+	// Start and Due are zero if unknown.
+	Start time.Time
+	Due   time.Time
+	// FromSprints is set if the epic has no own dates and they span its children's sprints.
+	FromSprints bool
+	// Children counts the child issues per status category key (new, indeterminate, done).
+	Children map[string]int
+}
+
+// Progress returns the number of done and of all children.
+func (e RoadmapEpic) Progress() (done, total int) {
+	for category, n := range e.Children {
+		total += n
+		if category == "done" {
+			done += n
+		}
+	}
+	return done, total
+}
+
+// RoadmapDependency is an issue link between two epics of the roadmap, e.g. From blocks To.
+type RoadmapDependency struct {
+	From string
+	To   string
+	Type string
+}
+
+// Roadmap holds the epics of some projects and the links between them.
+type Roadmap struct {
+	Fields       RoadmapFields
+	Epics        []RoadmapEpic
+	Dependencies []RoadmapDependency
+}
+
+type roadmapIssue struct {
+	Key    string                     `json:"key"`
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+// searchRoadmapIssues runs a JQL search and returns up to max issues with raw fields.
+func searchRoadmapIssues(domain, email, token, jql string, fields []string, max int) ([]roadmapIssue, error) {
+	url := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/search/jql", domain)
+	var issues []roadmapIssue
+	nextPageToken := ""
+	for len(issues) < max {
+		body := map[string]interface{}{
+			"jql":        jql,
+			"fields":     fields,
+			"maxResults": min(labelScanPageSize, max-len(issues)),
+		}
+		if nextPageToken != "" {
+			body["nextPageToken"] = nextPageToken
+		}
+		var page struct {
+			Issues        []roadmapIssue `json:"issues"`
+			NextPageToken string         `json:"nextPageToken"`
+			IsLast        bool           `json:"isLast"`
+		}
+		if err := jiraCall("POST", url, email, token, body, &page); err != nil {
+			return nil, err
+		}
+		issues = append(issues, page.Issues...)
+		if page.IsLast || page.NextPageToken == "" || len(page.Issues) == 0 {
+			break
+		}
+		nextPageToken = page.NextPageToken
+	}
+	return issues, nil
+}
+
+// parseJiraDate reads a date field, the zero time if it is empty.
+func parseJiraDate(raw json.RawMessage) time.Time {
+	var s string
+	if json.Unmarshal(raw, &s) != nil {
+		return time.Time{}
+	}
+	t, _ := time.ParseInLocation(jiraDate, s, time.Local)
+	return t
+}
+
+// FetchRoadmap loads the open and recently finished epics of the projects, the status
+// categories and sprints of their children and the links between them.
+func FetchRoadmap(domain, email, token string, projectKeys []string) (Roadmap, error) {
+	fields, err := FetchRoadmapFields(domain, email, token)
+	if err != nil {
+		return Roadmap{}, err
+	}
+	roadmap := Roadmap{Fields: fields}
+	if len(projectKeys) == 0 {
+		return roadmap, nil
+	}
+
+	types, err := FetchIssueTypes(domain, email, token)
+	if err != nil {
+		return roadmap, err
+	}
+	quoted := make([]string, len(projectKeys))
+	for i, k := range projectKeys {
+		quoted[i] = strconv.Quote(k)
+	}
+	jql := fmt.Sprintf(`project in (%s) AND %s AND (statusCategory != Done OR updated >= -30d) ORDER BY duedate ASC, key ASC`,
+		strings.Join(quoted, ", "), epicTypeJQL(EpicTypeIDs(types)))
+	epicFields := []string{"summary", "status", "project", "duedate", "issuelinks"}
+	if fields.StartDate != "" {
+		epicFields = append(epicFields, fields.StartDate)
+	}
+	epics, err := searchRoadmapIssues(domain, email, token, jql, epicFields, maxRoadmapEpics)
+	if err != nil {
+		return roadmap, err
+	}
+
+	index := map[string]int{}
+	var keys []string
+	for _, iss := range epics {
+		epic := RoadmapEpic{Key: iss.Key, Project: ProjectKeyOf(iss.Key), Due: parseJiraDate(iss.Fields["duedate"]), Children: map[string]int{}}
+		json.Unmarshal(iss.Fields["summary"], &epic.Summary)
+		json.Unmarshal(iss.Fields["status"], &epic.Status)
+		if fields.StartDate != "" {
+			epic.Start = parseJiraDate(iss.Fields[fields.StartDate])
+		}
+		index[epic.Key] = len(roadmap.Epics)
+		keys = append(keys, epic.Key)
+		roadmap.Epics = append(roadmap.Epics, epic)
+	}
+
+	// links are reported on both epics, each dependency is kept once
+	seen := map[RoadmapDependency]bool{}
+	for _, iss := range epics {
+		var links []JiraIssueLink
+		json.Unmarshal(iss.Fields["issuelinks"], &links)
+		for _, l := range links {
+			dep := RoadmapDependency{Type: l.Type.Name}
+			switch {
+			case l.OutwardIssue != nil:
+				dep.From, dep.To = iss.Key, l.OutwardIssue.Key
+			case l.InwardIssue != nil:
+				dep.From, dep.To = l.InwardIssue.Key, iss.Key
+			}
+			if _, ok := index[dep.From]; !ok {
+				continue
+			}
+			if _, ok := index[dep.To]; !ok || seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			roadmap.Dependencies = append(roadmap.Dependencies, dep)
+		}
+	}
+
+	childFields := []string{"status", "parent"}
+	if fields.Sprint != "" {
+		childFields = append(childFields, fields.Sprint)
+	}
+	sprintStart, sprintEnd := map[string]time.Time{}, map[string]time.Time{}
+	for start := 0; start < len(keys); start += roadmapChildBatch {
+		batch := keys[start:min(start+roadmapChildBatch, len(keys))]
+		children, err := searchRoadmapIssues(domain, email, token, fmt.Sprintf("parent in (%s)", strings.Join(batch, ", ")), childFields, maxRoadmapChildren)
+		if err != nil {
+			return roadmap, err
+		}
+		for _, child := range children {
+			var parent JiraIssueRef
+			var status JiraStatus
+			json.Unmarshal(child.Fields["parent"], &parent)
+			json.Unmarshal(child.Fields["status"], &status)
+			i, ok := index[parent.Key]
+			if !ok {
+				continue
+			}
+			roadmap.Epics[i].Children[status.StatusCategory.Key]++
+
+			var sprints []JiraSprint
+			json.Unmarshal(child.Fields[fields.Sprint], &sprints)
+			for _, s := range sprints {
+				from, errFrom := time.Parse(time.RFC3339, s.StartDate)
+				to, errTo := time.Parse(time.RFC3339, s.EndDate)
+				if errFrom != nil || errTo != nil {
+					continue
+				}
+				if t, ok := sprintStart[parent.Key]; !ok || from.Before(t) {
+					sprintStart[parent.Key] = from
+				}
+				if t, ok := sprintEnd[parent.Key]; !ok || to.After(t) {
+					sprintEnd[parent.Key] = to
+				}
+			}
+		}
+	}
+	for i := range roadmap.Epics {
+		epic := &roadmap.Epics[i]
+		if epic.Start.IsZero() && epic.Due.IsZero() {
+			if from, ok := sprintStart[epic.Key]; ok {
+				epic.Start, epic.Due, epic.FromSprints = from.Local(), sprintEnd[epic.Key].Local(), true
+			}
+		}
+	}
+	return roadmap, nil
+}
+
+// UpdateEpicDates sets start and due date of an epic. The start date is skipped if the
+// site has no start date field.
+func UpdateEpicDates(domain, email, token string, fields RoadmapFields, key string, start, due time.Time) error {
+	values := map[string]interface{}{"duedate": due.Format(jiraDate)}
+	if fields.StartDate != "" {
+		values[fields.StartDate] = start.Format(jiraDate)
+	}
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s", domain, key)
+	return jiraCall("PUT", u, email, token, map[string]interface{}{"fields": values}, nil)
+}
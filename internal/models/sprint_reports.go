@@ -0,0 +1,295 @@
+package models
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// jiraChangelogTime is the timestamp format of changelog entries.
+const jiraChangelogTime = "2006-01-02T15:04:05.000-0700"
+
+// BurndownPoint is the remaining work of a sprint at a point in time.
+type BurndownPoint struct {
+	Time      time.Time
+	Remaining float64
+	// Scope is all work in the sprint at that time, done or not.
+	Scope float64
+}
+
+// SprintReport is the burndown of a sprint and the work committed at its start and
+// completed at its end. Work is the sum of estimates, or the number of issues if the
+// report counts issues.
+type SprintReport struct {
+	Sprint    JiraSprint
+	Start     time.Time
+	End       time.Time
+	Burndown  []BurndownPoint
+	Committed float64
+	Completed float64
+}
+
+type changelogEntry struct {
+	Created string `json:"created"`
+	Items   []struct {
+		Field      string  `json:"field"`
+		FieldID    string  `json:"fieldId"`
+		From       *string `json:"from"`
+		To         *string `json:"to"`
+		FromString *string `json:"fromString"`
+		ToString   *string `json:"toString"`
+	} `json:"items"`
+}
+
+// issueChange is a change of estimate, done state or sprint membership of an issue.
+type issueChange struct {
+	at       time.Time
+	estimate *[2]float64
+	done     *[2]bool
+	inSprint *[2]bool
+}
+
+// issueState is what a report needs to know about an issue at one point in time.
+type issueState struct {
+	estimate float64
+	done     bool
+	inSprint bool
+}
+
+// issueHistory replays the changes of an issue backwards from its current state.
+type issueHistory struct {
+	current issueState
+	changes []issueChange // oldest first
+}
+
+// at returns the state at the given time by undoing all later changes.
+func (h issueHistory) at(t time.Time) issueState {
+	state := h.current
+	for i := len(h.changes) - 1; i >= 0 && h.changes[i].at.After(t); i-- {
+		c := h.changes[i]
+		if c.estimate != nil {
+			state.estimate = c.estimate[0]
+		}
+		if c.done != nil {
+			state.done = c.done[0]
+		}
+		if c.inSprint != nil {
+			state.inSprint = c.inSprint[0]
+		}
+	}
+	return state
+}
+
+// work is what the issue contributes to the report in the state.
+func (s issueState) work(countIssues bool) float64 {
+	if countIssues {
+		return 1
+	}
+	return s.estimate
+}
+
+// fetchChangelog returns all changelog entries of an issue, oldest first.
+func fetchChangelog(domain, email, token, issueKey string) ([]changelogEntry, error) {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/api/3/issue/%s/changelog?", domain, issueKey)
+	return fetchAgilePages[changelogEntry](u, email, token)
+}
+
+// BuildSprintReport computes burndown, committed and completed work of a sprint from the
+// changelogs of its issues. Without estimateField or with countIssues the issues are
+// counted. Issues that left the sprint and are no longer linked to it are not known to
+// Jira's sprint issue list and therefore missing from the report.
+func BuildSprintReport(domain, email, token string, boardID int, sprint JiraSprint, estimateField string, countIssues bool) (SprintReport, error) {
+	report := SprintReport{Sprint: sprint}
+	var err error
+	if report.Start, err = time.Parse(time.RFC3339, sprint.StartDate); err != nil {
+		return report, fmt.Errorf("sprint %s has not been started", sprint.Name)
+	}
+	if report.End, err = time.Parse(time.RFC3339, sprint.EndDate); err != nil {
+		return report, fmt.Errorf("sprint %s has no end date", sprint.Name)
+	}
+	if completed, err := time.Parse(time.RFC3339, sprint.CompleteDate); err == nil {
+		report.End = completed
+	} else if now := time.Now(); now.Before(report.End) {
+		report.End = now
+	}
+	if estimateField == "" {
+		countIssues = true
+	}
+
+	meta, err := LoadJiraMetadata(domain, email, token, false)
+	if err != nil {
+		return report, err
+	}
+	issues, err := FetchSprintIssues(domain, email, token, boardID, sprint.ID, estimateField)
+	if err != nil {
+		return report, err
+	}
+
+	var histories []issueHistory
+	var mu sync.Mutex
+	var firstErr error
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, BulkConcurrency)
+	for _, issue := range issues {
+		if issue.IssueType.Subtask {
+			continue
+		}
+		wg.Add(1)
+		go func(issue BoardIssue) {
+			defer wg.Done()
+			sem <- struct{}{}
+			entries, err := fetchChangelog(domain, email, token, issue.Key)
+			<-sem
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%s: %w", issue.Key, err)
+				}
+				return
+			}
+			histories = append(histories, newIssueHistory(issue, entries, sprint.ID, estimateField, meta))
+		}(issue)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return report, firstErr
+	}
+
+	// a point at the start, at every change during the sprint and at its end
+	times := []time.Time{report.Start}
+	for _, h := range histories {
+		for _, c := range h.changes {
+			if c.at.After(report.Start) && !c.at.After(report.End) {
+				times = append(times, c.at)
+			}
+		}
+	}
+	times = append(times, report.End)
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	for i, t := range times {
+		if i > 0 && t.Equal(times[i-1]) {
+			continue
+		}
+		point := BurndownPoint{Time: t}
+		for _, h := range histories {
+			state := h.at(t)
+			if !state.inSprint {
+				continue
+			}
+			point.Scope += state.work(countIssues)
+			if !state.done {
+				point.Remaining += state.work(countIssues)
+			}
+		}
+		report.Burndown = append(report.Burndown, point)
+	}
+
+	for _, h := range histories {
+		if start := h.at(report.Start); start.inSprint {
+			report.Committed += start.work(countIssues)
+		}
+		if end := h.at(report.End); end.inSprint && end.done {
+			report.Completed += end.work(countIssues)
+		}
+	}
+	return report, nil
+}
+
+func newIssueHistory(issue BoardIssue, entries []changelogEntry, sprintID int, estimateField string, meta *JiraMetadata) issueHistory {
+	isDone := func(statusID *string) bool {
+		return statusID != nil && meta.Statuses[*statusID].StatusCategory.Key == "done"
+	}
+	inSprint := func(ids *string) bool {
+		if ids == nil {
+			return false
+		}
+		for _, id := range strings.Split(*ids, ",") {
+			if strings.TrimSpace(id) == strconv.Itoa(sprintID) {
+				return true
+			}
+		}
+		return false
+	}
+	number := func(s *string) float64 {
+		if s == nil {
+			return 0
+		}
+		v, _ := strconv.ParseFloat(strings.TrimSpace(*s), 64)
+		return v
+	}
+
+	h := issueHistory{current: issueState{
+		estimate: issue.Estimate,
+		done:     issue.Status.StatusCategory.Key == "done",
+		inSprint: true,
+	}}
+	for _, e := range entries {
+		at, err := time.Parse(jiraChangelogTime, e.Created)
+		if err != nil {
+			continue
+		}
+		for _, item := range e.Items {
+			change := issueChange{at: at}
+			switch {
+			case estimateField != "" && item.FieldID == estimateField:
+				change.estimate = &[2]float64{number(item.FromString), number(item.ToString)}
+			case item.FieldID == "status" || item.Field == "status":
+				change.done = &[2]bool{isDone(item.From), isDone(item.To)}
+			case item.Field == "Sprint":
+				change.inSprint = &[2]bool{inSprint(item.From), inSprint(item.To)}
+			default:
+				continue
+			}
+			h.changes = append(h.changes, change)
+		}
+	}
+	sort.SliceStable(h.changes, func(i, j int) bool { return h.changes[i].at.Before(h.changes[j].at) })
+	return h
+}
+
+// FetchRecentSprints returns the last closed sprints of a board, oldest first.
+func FetchRecentSprints(domain, email, token string, boardID, count int) ([]JiraSprint, error) {
+	sprints, err := FetchSprints(domain, email, token, boardID, SprintClosed)
+	if err != nil {
+		return nil, err
+	}
+	// sprints of other boards that were shown on this one may be listed out of order
+	sort.SliceStable(sprints, func(i, j int) bool { return sprints[i].CompleteDate < sprints[j].CompleteDate })
+	if len(sprints) > count {
+		sprints = sprints[len(sprints)-count:]
+	}
+	return sprints, nil
+}
+
+// WriteBurndownCSV writes the burndown points of a report.
+func WriteBurndownCSV(w io.Writer, report SprintReport) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"time", "remaining", "scope"})
+	for _, p := range report.Burndown {
+		out.Write([]string{p.Time.Format(time.RFC3339), formatFloat(p.Remaining), formatFloat(p.Scope)})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// WriteVelocityCSV writes committed and completed work per sprint.
+func WriteVelocityCSV(w io.Writer, reports []SprintReport) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"sprint", "start", "end", "committed", "completed"})
+	for _, r := range reports {
+		out.Write([]string{r.Sprint.Name, r.Start.Format(time.RFC3339), r.End.Format(time.RFC3339), formatFloat(r.Committed), formatFloat(r.Completed)})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
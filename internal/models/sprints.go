@@ -0,0 +1,98 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// StartSprint activates a future sprint with the given name, goal and dates.
+func StartSprint(domain, email, token string, sprintID int, name, goal string, start, end time.Time) error {
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/sprint/%d", domain, sprintID)
+	payload := map[string]interface{}{
+		"state":     SprintActive,
+		"name":      name,
+		"goal":      goal,
+		"startDate": start.Format(time.RFC3339),
+		"endDate":   end.Format(time.RFC3339),
+	}
+	return jiraCall("POST", u, email, token, payload, nil)
+}
+
+// CompleteSprint moves the incomplete issues to another sprint, or to the backlog if
+// moveTo is 0, and closes the sprint.
+func CompleteSprint(domain, email, token string, sprintID int, incomplete []string, moveTo int) error {
+	if len(incomplete) > 0 {
+		var err error
+		if moveTo != 0 {
+			err = MoveIssuesToSprint(domain, email, token, moveTo, incomplete)
+		} else {
+			err = MoveIssuesToBacklog(domain, email, token, incomplete)
+		}
+		if err != nil {
+			return fmt.Errorf("moving incomplete issues: %w", err)
+		}
+	}
+	u := fmt.Sprintf("https://%s.atlassian.net/rest/agile/1.0/sprint/%d", domain, sprintID)
+	return jiraCall("POST", u, email, token, map[string]interface{}{"state": SprintClosed}, nil)
+}
+
+// SprintCapacities are the planned capacities (in estimate units) of a board's sprints.
+// Sprints without an own value use Default; 0 means no capacity is set.
+type SprintCapacities struct {
+	Default float64         `json:"default"`
+	Sprints map[int]float64 `json:"sprints,omitempty"`
+}
+
+// Of returns the capacity of the sprint.
+func (c SprintCapacities) Of(sprintID int) float64 {
+	if v, ok := c.Sprints[sprintID]; ok {
+		return v
+	}
+	return c.Default
+}
+
+// Set stores the capacity of a sprint; 0 as sprintID changes the default.
+func (c *SprintCapacities) Set(sprintID int, capacity float64) {
+	if sprintID == 0 {
+		c.Default = capacity
+		return
+	}
+	if c.Sprints == nil {
+		c.Sprints = map[int]float64{}
+	}
+	c.Sprints[sprintID] = capacity
+}
+
+// Reset drops the own capacity of the sprint so the default applies again.
+func (c *SprintCapacities) Reset(sprintID int) {
+	delete(c.Sprints, sprintID)
+}
+
+// LoadSprintCapacities returns the capacities stored for the board.
+func LoadSprintCapacities(prefs fyne.Preferences, boardID int) SprintCapacities {
+	var capacities SprintCapacities
+	if raw := prefs.String(sprintCapacityKey(boardID)); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &capacities); err != nil {
+			fmt.Println("Error reading sprint capacities:", err)
+		}
+	}
+	return capacities
+}
+
+// SaveSprintCapacities stores the capacities of the board.
+func SaveSprintCapacities(prefs fyne.Preferences, boardID int, capacities SprintCapacities) {
+	data, err := json.Marshal(capacities)
+	if err != nil {
+		fmt.Println("Error saving sprint capacities:", err)
+		return
+	}
+	prefs.SetString(sprintCapacityKey(boardID), string(data))
+}
+
+func sprintCapacityKey(boardID int) string {
+	return "sprint_capacity_" + strconv.Itoa(boardID)
+}
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// IssueTemplate prefills the create form. Empty Project or IssueType means "any".
+type IssueTemplate struct {
+	Name         string   `json:"name"`
+	Project      string   `json:"project,omitempty"`
+	IssueType    string   `json:"issueType,omitempty"`
+	TitlePattern string   `json:"titlePattern"`
+	Description  string   `json:"description"`
+	Labels       []string `json:"labels,omitempty"`
+}
+
+// templateExport is the file format used for import and export.
+type templateExport struct {
+	Version   int             `json:"version"`
+	Templates []IssueTemplate `json:"templates"`
+}
+
+// LoadTemplates returns all saved issue templates.
+func LoadTemplates(prefs fyne.Preferences) []IssueTemplate {
+	var templates []IssueTemplate
+	if raw := prefs.String("issue_templates"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &templates); err != nil {
+			fmt.Println("Error reading issue templates:", err)
+			return nil
+		}
+	}
+	return templates
+}
+
+// SaveTemplates stores the issue templates.
+func SaveTemplates(prefs fyne.Preferences, templates []IssueTemplate) error {
+	data, err := json.Marshal(templates)
+	if err != nil {
+		return err
+	}
+	prefs.SetString("issue_templates", string(data))
+	return nil
+}
+
+// TemplatesFor returns the templates that apply to the given project and issue type.
+func TemplatesFor(templates []IssueTemplate, projectKey, issueType string) []IssueTemplate {
+	var out []IssueTemplate
+	for _, t := range templates {
+		if t.Project != "" && !strings.EqualFold(t.Project, projectKey) {
+			continue
+		}
+		if t.IssueType != "" && !strings.EqualFold(t.IssueType, issueType) {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// ExportTemplates writes the templates as indented JSON.
+func ExportTemplates(w io.Writer, templates []IssueTemplate) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(templateExport{Version: 1, Templates: templates})
+}
+
+// ImportTemplates reads templates written by ExportTemplates. A plain JSON array is accepted as well.
+func ImportTemplates(r io.Reader) ([]IssueTemplate, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var export templateExport
+	if err := json.Unmarshal(data, &export); err == nil && export.Templates != nil {
+		return validTemplates(export.Templates)
+	}
+
+	var templates []IssueTemplate
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return nil, fmt.Errorf("invalid template file: %w", err)
+	}
+	return validTemplates(templates)
+}
+
+func validTemplates(templates []IssueTemplate) ([]IssueTemplate, error) {
+	for i, t := range templates {
+		if strings.TrimSpace(t.Name) == "" {
+			return nil, fmt.Errorf("template %d has no name", i+1)
+		}
+		if j := TemplateNameIndex(templates[:i], t.Name); j >= 0 {
+			return nil, fmt.Errorf("templates %d and %d are both named %q", j+1, i+1, t.Name)
+		}
+	}
+	return templates, nil
+}
+
+// TemplateNameIndex returns the index of the template with the name, ignoring case,
+// or -1. Templates are picked by name, so names must be unique.
+func TemplateNameIndex(templates []IssueTemplate, name string) int {
+	for i, t := range templates {
+		if strings.EqualFold(strings.TrimSpace(t.Name), strings.TrimSpace(name)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// MergeTemplates adds imported templates, replacing existing ones with the same name
+// (ignoring case).
+func MergeTemplates(existing, imported []IssueTemplate) []IssueTemplate {
+	out := append([]IssueTemplate{}, existing...)
+	for _, t := range imported {
+		if i := TemplateNameIndex(out, t.Name); i >= 0 {
+			out[i] = t
+		} else {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// TemplateVariables returns the default placeholder values for ExpandTemplate.
+func TemplateVariables(user, component string) map[string]string {
+	return map[string]string{
+		"date":      time.Now().Format("2006-01-02"),
+		"user":      user,
+		"component": component,
+	}
+}
+
+// ExpandTemplate replaces {{name}} placeholders with the given values.
+// Unknown placeholders are left untouched.
+func ExpandTemplate(text string, vars map[string]string) string {
+	for name, value := range vars {
+		text = strings.ReplaceAll(text, "{{"+name+"}}", value)
+	}
+	return text
+}
+
+// UsesPlaceholder reports whether the template contains {{name}} in title or description.
+func (t IssueTemplate) UsesPlaceholder(name string) bool {
+	p := "{{" + name + "}}"
+	return strings.Contains(t.TitlePattern, p) || strings.Contains(t.Description, p)
+}
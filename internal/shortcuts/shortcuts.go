@@ -0,0 +1,165 @@
+package shortcuts
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// Action IDs of all commands that can be bound to a key.
+const (
+	ActionPalette    = "palette"
+	ActionHelp       = "help"
+	ActionGoTo       = "goto"
+	ActionCreate     = "create"
+	ActionReload     = "reload"
+	ActionSettings   = "settings"
+	ActionNext       = "next"
+	ActionPrevious   = "previous"
+	ActionOpen       = "open"
+	ActionBack       = "back"
+	ActionComment    = "comment"
+	ActionTransition = "transition"
+)
+
+// Definition describes a bindable action with its default key binding.
+// Bindings are written as "Mod+K", "Shift+J" or "Escape"; Mod is Cmd on macOS and Ctrl elsewhere.
+type Definition struct {
+	ID             string
+	LabelKey       string
+	DefaultBinding string
+}
+
+// Definitions lists all actions in the order they are shown in the help overlay.
+var Definitions = []Definition{
+	{ActionPalette, "shortcuts.palette", "Mod+K"},
+	{ActionHelp, "shortcuts.help", "F1"},
+	{ActionGoTo, "shortcuts.goto", "Mod+G"},
+	{ActionCreate, "shortcuts.create", "Mod+N"},
+	{ActionReload, "shortcuts.reload", "Mod+R"},
+	{ActionSettings, "shortcuts.settings", "Mod+,"},
+	{ActionNext, "shortcuts.next", "J"},
+	{ActionPrevious, "shortcuts.previous", "K"},
+	{ActionOpen, "shortcuts.open", "Return"},
+	{ActionBack, "shortcuts.back", "Escape"},
+	{ActionComment, "shortcuts.comment", "C"},
+	{ActionTransition, "shortcuts.transition", "T"},
+}
+
+// Binding is a parsed key binding.
+type Binding struct {
+	Key      fyne.KeyName
+	Modifier fyne.KeyModifier
+}
+
+func prefKey(id string) string {
+	return fmt.Sprintf("shortcut_%s", id)
+}
+
+// Get returns the binding string for an action, falling back to its default.
+func Get(prefs fyne.Preferences, id string) string {
+	for _, d := range Definitions {
+		if d.ID == id {
+			return prefs.StringWithFallback(prefKey(id), d.DefaultBinding)
+		}
+	}
+	return ""
+}
+
+// Set stores a new binding for an action and notifies listeners.
+// An empty binding restores the default.
+func Set(prefs fyne.Preferences, id, binding string) error {
+	if binding == "" {
+		prefs.RemoveValue(prefKey(id))
+		return nil
+	}
+	if _, err := Parse(binding); err != nil {
+		return err
+	}
+	prefs.SetString(prefKey(id), binding)
+	return nil
+}
+
+// Parse converts a binding string into a key name and modifier.
+func Parse(binding string) (Binding, error) {
+	parts := strings.Split(binding, "+")
+	// "Mod++" binds the plus key
+	if strings.HasSuffix(binding, "++") {
+		parts = append(strings.Split(strings.TrimSuffix(binding, "++"), "+"), "+")
+	}
+
+	var b Binding
+	for i, p := range parts {
+		p = strings.TrimSpace(p)
+		if i == len(parts)-1 {
+			if p == "" {
+				return Binding{}, fmt.Errorf("invalid shortcut: %q", binding)
+			}
+			if len([]rune(p)) == 1 {
+				p = strings.ToUpper(p)
+			}
+			b.Key = fyne.KeyName(p)
+			break
+		}
+
+		switch strings.ToLower(p) {
+		case "mod":
+			b.Modifier |= fyne.KeyModifierShortcutDefault
+		case "ctrl", "control":
+			b.Modifier |= fyne.KeyModifierControl
+		case "shift":
+			b.Modifier |= fyne.KeyModifierShift
+		case "alt", "option":
+			b.Modifier |= fyne.KeyModifierAlt
+		case "cmd", "super":
+			b.Modifier |= fyne.KeyModifierSuper
+		default:
+			return Binding{}, fmt.Errorf("invalid modifier %q in shortcut %q", p, binding)
+		}
+	}
+	return b, nil
+}
+
+// Display renders a binding string for the current platform, e.g. "Mod+K" as "⌘+K" on macOS.
+func Display(binding string) string {
+	mod := "Ctrl"
+	if runtime.GOOS == "darwin" {
+		mod = "⌘"
+	}
+	return strings.ReplaceAll(binding, "Mod", mod)
+}
+
+type changeCallback struct {
+	id int
+	cb func()
+}
+
+var (
+	onChangeCallbacks []changeCallback
+	nextCallbackID    int
+)
+
+// RegisterOnChange registers a callback that runs after bindings have been changed.
+// The returned function removes the callback again.
+func RegisterOnChange(cb func()) (unregister func()) {
+	nextCallbackID++
+	id := nextCallbackID
+	onChangeCallbacks = append(onChangeCallbacks, changeCallback{id: id, cb: cb})
+	return func() {
+		for i, c := range onChangeCallbacks {
+			if c.id == id {
+				onChangeCallbacks = append(onChangeCallbacks[:i:i], onChangeCallbacks[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// NotifyChanged informs all listeners that the bindings have been changed.
+func NotifyChanged() {
+	for _, c := range onChangeCallbacks {
+		c.cb()
+	}
+}
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// rankCandidates is the number of ranked issues offered as "rank above" targets.
+const rankCandidates = 20
+
+// agileOptions lets the user pick board, sprint and rank position for a new issue.
+type agileOptions struct {
+	object fyne.CanvasObject
+
+	prefs               fyne.Preferences
+	domain, user, token string
+	projectKey          string
+
+	boardSelect  *widget.Select
+	sprintSelect *widget.Select
+	rankSelect   *widget.Select
+
+	boards  map[string]int
+	sprints map[string]int
+	// rank option -> issue to rank above, "" keeps Jira's default (bottom)
+	rankTargets map[string]string
+	request     int
+}
+
+func newAgileOptions(prefs fyne.Preferences, domain, user, token string) *agileOptions {
+	a := &agileOptions{prefs: prefs, domain: domain, user: user, token: token}
+	a.boardSelect = widget.NewSelect([]string{}, a.boardChanged)
+	a.boardSelect.PlaceHolder = i18n.T("agile.no_board")
+	a.sprintSelect = widget.NewSelect([]string{}, func(string) { a.loadRankTargets() })
+	a.rankSelect = widget.NewSelect([]string{}, nil)
+
+	a.object = container.NewGridWithColumns(3,
+		container.NewVBox(i18n.BindLabel("agile.board"), a.boardSelect),
+		container.NewVBox(i18n.BindLabel("agile.sprint"), a.sprintSelect),
+		container.NewVBox(i18n.BindLabel("agile.rank"), a.rankSelect),
+	)
+	a.reset()
+	return a
+}
+
+func (a *agileOptions) reset() {
+	a.boards = map[string]int{}
+	a.boardSelect.Options = nil
+	a.boardSelect.ClearSelected()
+	a.boardSelect.Disable()
+	a.resetSprints()
+}
+
+func (a *agileOptions) resetSprints() {
+	backlog := i18n.T("agile.backlog")
+	a.sprints = map[string]int{backlog: 0}
+	a.sprintSelect.Options = []string{backlog}
+	a.sprintSelect.Selected = backlog
+	a.sprintSelect.Refresh()
+	a.sprintSelect.Disable()
+
+	bottom := i18n.T("agile.rank_bottom")
+	a.rankTargets = map[string]string{bottom: ""}
+	a.rankSelect.Options = []string{bottom}
+	a.rankSelect.Selected = bottom
+	a.rankSelect.Refresh()
+	a.rankSelect.Disable()
+}
+
+// LoadProject loads the boards of the project and selects the last used one.
+func (a *agileOptions) LoadProject(projectKey string) {
+	a.projectKey = projectKey
+	a.request++
+	request := a.request
+	a.reset()
+
+	go func() {
+		boards, err := models.FetchBoards(a.domain, a.user, a.token, projectKey)
+		if err != nil {
+			// Jira Work Management projects have no boards, creating works without
+			fmt.Println("Error loading boards:", err)
+			return
+		}
+		fyne.Do(func() {
+			if request != a.request || len(boards) == 0 {
+				return
+			}
+			last := a.prefs.Int("board_" + projectKey)
+			var names []string
+			selected := ""
+			for _, b := range boards {
+				name := fmt.Sprintf("%s (%s)", b.Name, b.Type)
+				a.boards[name] = b.ID
+				names = append(names, name)
+				if b.ID == last || selected == "" {
+					selected = name
+				}
+			}
+			a.boardSelect.Options = names
+			a.boardSelect.Enable()
+			a.boardSelect.SetSelected(selected)
+		})
+	}()
+}
+
+func (a *agileOptions) boardChanged(name string) {
+	boardID, ok := a.boards[name]
+	a.resetSprints()
+	if !ok {
+		return
+	}
+	a.prefs.SetInt("board_"+a.projectKey, boardID)
+	a.request++
+	request := a.request
+
+	go func() {
+		sprints, err := models.FetchSprints(a.domain, a.user, a.token, boardID, models.SprintActive, models.SprintFuture)
+		fyne.Do(func() {
+			if request != a.request {
+				return
+			}
+			if err != nil {
+				// Kanban boards have no sprints
+				fmt.Println("Error loading sprints:", err)
+			}
+			for _, s := range sprints {
+				name := s.Name
+				if s.State == models.SprintActive {
+					name = fmt.Sprintf(i18n.T("agile.sprint_active"), s.Name)
+				}
+				a.sprints[name] = s.ID
+				a.sprintSelect.Options = append(a.sprintSelect.Options, name)
+			}
+			a.sprintSelect.Enable()
+			a.sprintSelect.Refresh()
+			a.loadRankTargets()
+		})
+	}()
+}
+
+// loadRankTargets offers the top issues of the chosen sprint or backlog as rank positions.
+func (a *agileOptions) loadRankTargets() {
+	boardID, ok := a.boards[a.boardSelect.Selected]
+	if !ok {
+		return
+	}
+	sprintID := a.sprints[a.sprintSelect.Selected]
+	a.request++
+	request := a.request
+
+	go func() {
+		issues, err := models.FetchRankedIssues(a.domain, a.user, a.token, boardID, sprintID, rankCandidates)
+		fyne.Do(func() {
+			if request != a.request {
+				return
+			}
+			bottom := i18n.T("agile.rank_bottom")
+			a.rankTargets = map[string]string{bottom: ""}
+			options := []string{bottom}
+			if err != nil {
+				fmt.Println("Error loading ranked issues:", err)
+			}
+			if len(issues) > 0 {
+				top := i18n.T("agile.rank_top")
+				a.rankTargets[top] = issues[0].Key
+				options = append(options, top)
+				for _, iss := range issues[1:] {
+					option := fmt.Sprintf(i18n.T("agile.rank_above"), iss.Key, iss.Fields.Summary)
+					a.rankTargets[option] = iss.Key
+					options = append(options, option)
+				}
+			}
+			a.rankSelect.Options = options
+			a.rankSelect.SetSelected(bottom)
+			a.rankSelect.Enable()
+		})
+	}()
+}
+
+// Settings returns the chosen sprint (0 = backlog) and the issue to rank above ("" = no ranking).
+func (a *agileOptions) Settings() (sprintID int, rankBefore string) {
+	return a.sprints[a.sprintSelect.Selected], a.rankTargets[a.rankSelect.Selected]
+}
+
+// applyAgileSettings moves a created issue into the chosen sprint and ranks it.
+func applyAgileSettings(domain, user, token, key string, sprintID int, rankBefore string) error {
+	if sprintID != 0 {
+		if err := models.MoveIssuesToSprint(domain, user, token, sprintID, []string{key}); err != nil {
+			return err
+		}
+	}
+	if rankBefore != "" {
+		return models.RankIssues(domain, user, token, []string{key}, rankBefore)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"github.com/scramb/backlog-manager/internal/i18n"
+)
+
+// AgileView combines the views working on Jira Software boards. The returned function
+// loads the data of the visible view on first use, it is called when the tab is selected.
+func AgileView(app fyne.App, w fyne.Window, domain, user, token string, openIssue func(key string)) (fyne.CanvasObject, func()) {
+	board := newBoardView(app, w, domain, user, token, openIssue)
+	planning := newSprintPlanningView(app, w, domain, user, token, openIssue)
+	reports := newSprintReportsView(app, w, domain, user, token)
+	roadmap := newRoadmapView(app, w, domain, user, token, openIssue)
+	poker := newPokerView(app, w, domain, user, token, openIssue)
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem(i18n.T("agile.tab_board"), board.object),
+		container.NewTabItem(i18n.T("agile.tab_planning"), planning.object),
+		container.NewTabItem(i18n.T("agile.tab_reports"), reports.object),
+		container.NewTabItem(i18n.T("agile.tab_roadmap"), roadmap.object),
+		container.NewTabItem(i18n.T("agile.tab_poker"), poker.object),
+	)
+	loaders := []func(){board.Load, planning.Load, reports.Load, roadmap.Load, poker.Load}
+	load := func() {
+		loaders[tabs.SelectedIndex()]()
+	}
+	tabs.OnSelected = func(*container.TabItem) { load() }
+
+	i18n.RegisterOnLanguageChange(func() {
+		fyne.Do(func() {
+			tabs.Items[0].Text = i18n.T("agile.tab_board")
+			tabs.Items[1].Text = i18n.T("agile.tab_planning")
+			tabs.Items[2].Text = i18n.T("agile.tab_reports")
+			tabs.Items[3].Text = i18n.T("agile.tab_roadmap")
+			tabs.Items[4].Text = i18n.T("agile.tab_poker")
+			tabs.Refresh()
+		})
+	})
+	return tabs, load
+}
@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/helper"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// attachmentEditor adds images to a multi-line entry. Every image is inserted as a
+// placeholder line at the cursor and uploaded as inline attachment on submit.
+type attachmentEditor struct {
+	object fyne.CanvasObject
+
+	app    fyne.App
+	w      fyne.Window
+	entry  *widget.Entry
+	thumbs *fyne.Container
+	paths  []string
+
+	// OnChanged is called when images are added or removed.
+	OnChanged func()
+}
+
+// newAttachmentEditor builds the paste/add buttons and the thumbnail row for entry.
+// Image files dropped onto the entry are added as well; dropID identifies the drop target.
+func newAttachmentEditor(app fyne.App, w fyne.Window, entry *widget.Entry, dropID string) *attachmentEditor {
+	e := &attachmentEditor{app: app, w: w, entry: entry, thumbs: container.NewHBox()}
+
+	pasteBtn := i18n.BindButton("attachments.paste", theme.ContentPasteIcon(), e.paste)
+	addBtn := i18n.BindButton("attachments.add", theme.FileImageIcon(), func() {
+		d := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if reader == nil {
+				return
+			}
+			reader.Close()
+			e.Add(reader.URI().Path())
+		}, w)
+		d.SetFilter(storage.NewExtensionFileFilter(helper.ImageExtensions))
+		d.Show()
+	})
+
+	registerDropTarget(w, dropID, entry, func(uris []fyne.URI) {
+		for _, u := range uris {
+			if helper.IsImageFile(u.Path()) {
+				e.Add(u.Path())
+			}
+		}
+	})
+
+	e.object = container.NewVBox(
+		container.NewHBox(pasteBtn, addBtn),
+		container.NewHScroll(e.thumbs),
+	)
+	return e
+}
+
+// storageDir is where pasted screenshots and renamed copies are kept until they are uploaded.
+func (e *attachmentEditor) storageDir() string {
+	return attachmentStorageDir(e.app)
+}
+
+func attachmentStorageDir(app fyne.App) string {
+	return filepath.Join(app.Storage().RootURI().Path(), "attachments")
+}
+
+// removeStoredAttachments deletes the pasted screenshots and renamed copies among paths
+// that are not in use anymore; images elsewhere belong to the user and are kept.
+func removeStoredAttachments(app fyne.App, paths []string, inUse map[string]bool) {
+	dir := attachmentStorageDir(app)
+	for _, p := range paths {
+		if inUse[p] || filepath.Dir(p) != dir {
+			continue
+		}
+		if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			fmt.Println("Error removing attachment:", err)
+		}
+	}
+}
+
+// paste adds an image file path from the clipboard text or the image in the clipboard.
+func (e *attachmentEditor) paste() {
+	text := strings.TrimSpace(e.app.Clipboard().Content())
+	text = strings.TrimPrefix(text, "file://")
+	if text != "" && helper.IsImageFile(text) {
+		if _, err := os.Stat(text); err == nil {
+			e.Add(text)
+			return
+		}
+	}
+
+	go func() {
+		path, err := helper.SaveClipboardImage(e.storageDir())
+		fyne.Do(func() {
+			if errors.Is(err, helper.ErrNoClipboardImage) {
+				dialog.ShowInformation(i18n.T("attachments.paste"), i18n.T("attachments.no_image"), e.w)
+				return
+			}
+			if err != nil {
+				dialog.ShowError(err, e.w)
+				return
+			}
+			e.Add(path)
+		})
+	}()
+}
+
+// Add inserts the image below the cursor line. Files with the name of an image that
+// was already added are copied under a new name, since the placeholder uses the name.
+func (e *attachmentEditor) Add(path string) {
+	for _, p := range e.paths {
+		if p == path {
+			return
+		}
+	}
+	if e.hasName(filepath.Base(path)) {
+		copied, err := e.copyWithUniqueName(path)
+		if err != nil {
+			dialog.ShowError(err, e.w)
+			return
+		}
+		path = copied
+	}
+
+	lines := strings.Split(e.entry.Text, "\n")
+	row := e.entry.CursorRow
+	if row >= len(lines) {
+		row = len(lines) - 1
+	}
+	placeholder := models.AttachmentPlaceholder(filepath.Base(path))
+	if e.entry.Text == "" {
+		lines = []string{placeholder}
+	} else {
+		lines = append(lines[:row+1], append([]string{placeholder}, lines[row+1:]...)...)
+	}
+	e.entry.SetText(strings.Join(lines, "\n"))
+
+	e.paths = append(e.paths, path)
+	e.refresh()
+}
+
+// Remove drops the image and its placeholder from the text.
+func (e *attachmentEditor) Remove(path string) {
+	placeholder := models.AttachmentPlaceholder(filepath.Base(path))
+	var lines []string
+	for _, line := range strings.Split(e.entry.Text, "\n") {
+		if strings.TrimSpace(line) != placeholder {
+			lines = append(lines, line)
+		}
+	}
+	e.entry.SetText(strings.Join(lines, "\n"))
+
+	out := e.paths[:0]
+	for _, p := range e.paths {
+		if p != path {
+			out = append(out, p)
+		}
+	}
+	e.paths = out
+	e.refresh()
+}
+
+// Paths returns the images to upload.
+func (e *attachmentEditor) Paths() []string {
+	return append([]string{}, e.paths...)
+}
+
+// SetPaths replaces the images without touching the text, e.g. when a draft is restored.
+func (e *attachmentEditor) SetPaths(paths []string) {
+	e.paths = nil
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			e.paths = append(e.paths, p)
+		}
+	}
+	e.refresh()
+}
+
+func (e *attachmentEditor) hasName(name string) bool {
+	for _, p := range e.paths {
+		if filepath.Base(p) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *attachmentEditor) copyWithUniqueName(path string) (string, error) {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(filepath.Base(path), ext)
+	name := ""
+	for i := 2; ; i++ {
+		name = fmt.Sprintf("%s-%d%s", base, i, ext)
+		if !e.hasName(name) {
+			break
+		}
+	}
+
+	if err := os.MkdirAll(e.storageDir(), 0o755); err != nil {
+		return "", err
+	}
+	src, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+	target := filepath.Join(e.storageDir(), name)
+	dst, err := os.Create(target)
+	if err != nil {
+		return "", err
+	}
+	defer dst.Close()
+	if _, err := io.Copy(dst, src); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+func (e *attachmentEditor) refresh() {
+	e.thumbs.Objects = nil
+	for _, p := range e.paths {
+		path := p
+		img := canvas.NewImageFromFile(path)
+		img.FillMode = canvas.ImageFillContain
+		img.SetMinSize(fyne.NewSize(96, 72))
+
+		removeBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() { e.Remove(path) })
+		removeBtn.Importance = widget.LowImportance
+		name := widget.NewLabel(filepath.Base(path))
+		name.Truncation = fyne.TextTruncateEllipsis
+
+		e.thumbs.Add(container.NewBorder(nil, container.NewBorder(nil, nil, nil, removeBtn, name), nil, nil, img))
+	}
+	e.thumbs.Refresh()
+	if e.OnChanged != nil {
+		e.OnChanged()
+	}
+}
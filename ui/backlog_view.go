@@ -1,8 +1,12 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"image/color"
+	"sort"
 	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -11,12 +15,40 @@ import (
 	"fyne.io/fyne/v2/widget"
 	"github.com/scramb/backlog-manager/internal/i18n"
 	"github.com/scramb/backlog-manager/internal/models"
+	"github.com/scramb/backlog-manager/ui/components"
+	"github.com/scramb/backlog-manager/ui/settings"
 )
 
+// BacklogPrefill presets the create form, e.g. for creating a sub-task of an open issue.
+type BacklogPrefill struct {
+	ProjectKey    string
+	ParentKey     string
+	ParentSummary string
+	Subtask       bool
+	// Autosave keeps the form content as a local draft and restores it on start.
+	Autosave bool
+	// OpenIssue shows an issue in Jirion, e.g. right after it was created. May be nil.
+	OpenIssue func(key string)
+	// Draft fills the form on start, e.g. with the fields of a cloned issue. May be nil.
+	Draft *models.Draft
+	// AfterCreate runs in the background once the issue exists, with the form content it
+	// was created from. Its error is shown, the issue stays created. May be nil.
+	AfterCreate func(created models.CreatedIssue, form models.Draft) error
+}
+
 // NewBacklogView builds the Create Backlog tab content
 // It handles loading favourite projects, per-project issue types,
 // OpenAI generation, and creating the Jira issue.
 func BacklogView(app fyne.App, w fyne.Window, domain, user, token string) fyne.CanvasObject {
+	return BacklogViewWithPrefill(app, w, domain, user, token, BacklogPrefill{Autosave: true})
+}
+
+// BacklogViewWithPrefill builds the create form with the given presets.
+func BacklogViewWithPrefill(app fyne.App, w fyne.Window, domain, user, token string, prefill BacklogPrefill) fyne.CanvasObject {
+	prefs := app.Preferences()
+	// set once all inputs exist, widgets call it on every change
+	scheduleAutosave := func() {}
+
 	// Inputs
 	titleEntry := widget.NewEntry()
 	contentEntry := widget.NewMultiLineEntry()
@@ -27,28 +59,188 @@ func BacklogView(app fyne.App, w fyne.Window, domain, user, token string) fyne.C
 	issueType := widget.NewSelect([]string{i18n.T("backlog.load_types")}, nil)
 	issueType.Disable()
 
-	labelChecks := map[string]*widget.Check{}
-	updateLabelsGrid := func(savedLabels []string) fyne.CanvasObject {
-		numLabels := len(savedLabels)
-		if numLabels == 0 {
-			return i18n.BindLabel("backlog.no_labels")
+	labelsInput := components.NewTagInput(i18n.T("backlog.labels_placeholder"))
+	i18n.RegisterOnLanguageChange(func() {
+		fyne.Do(func() { labelsInput.SetPlaceHolder(i18n.T("backlog.labels_placeholder")) })
+	})
+	labelsInput.Validate = settings.ValidateLabel
+	labelsInput.OnChanged = func([]string) { scheduleAutosave() }
+
+	// suggestions: favourites of the current project first, then all its labels by usage
+	var favouriteLabels, projectLabels []string
+	var labelFavourites models.LabelFavourites
+	labelsInput.Color = func(label string) color.Color {
+		if c, ok := components.HexColor(labelFavourites.Color(label)); ok {
+			return c
 		}
+		return nil
+	}
+	labelsInput.Suggest = func() []string {
+		seen := map[string]bool{}
+		var out []string
+		for _, l := range append(append([]string{}, favouriteLabels...), projectLabels...) {
+			if !seen[l] {
+				seen[l] = true
+				out = append(out, l)
+			}
+		}
+		return out
+	}
+	labelsRequest := 0
+	currentProjectKey := ""
+
+	// values of a restored draft, applied once the project's issue types and labels are loaded
+	pendingProject := prefill.ProjectKey
+	pendingType := ""
+	currentDraftID := ""
+	currentDraftName := ""
+
+	templateSelect := widget.NewSelect([]string{}, nil)
+	templateSelect.PlaceHolder = i18n.T("backlog.template_none")
+	var availableTemplates []models.IssueTemplate
+
+	updateTemplates := func() {
+		availableTemplates = models.TemplatesFor(models.LoadTemplates(app.Preferences()), currentProjectKey, issueType.Selected)
+		var names []string
+		for _, t := range availableTemplates {
+			names = append(names, t.Name)
+		}
+		templateSelect.Options = names
+		templateSelect.ClearSelected()
+		templateSelect.Refresh()
+	}
 
-		labelsPerRow := 3
-		labelChecks = make(map[string]*widget.Check)
-		gridObjects := []fyne.CanvasObject{}
+	applyTemplate := func(t models.IssueTemplate, component string) {
+		vars := models.TemplateVariables(user, component)
+		titleEntry.SetText(models.ExpandTemplate(t.TitlePattern, vars))
+		contentEntry.SetText(models.ExpandTemplate(t.Description, vars))
+		labelsInput.SetTags(append(labelsInput.Tags(), t.Labels...))
+	}
+
+	templateSelect.OnChanged = func(name string) {
+		for _, t := range availableTemplates {
+			if t.Name != name {
+				continue
+			}
+			if !t.UsesPlaceholder("component") {
+				applyTemplate(t, "")
+				return
+			}
+			template := t
+			componentEntry := widget.NewEntry()
+			dialog.ShowForm(i18n.T("backlog.template_component"), i18n.T("bulk.apply"), i18n.T("bulk.cancel"),
+				[]*widget.FormItem{widget.NewFormItem(i18n.T("backlog.component"), componentEntry)},
+				func(ok bool) {
+					if ok {
+						applyTemplate(template, componentEntry.Text)
+					}
+				}, w)
+			return
+		}
+	}
+	// create screen fields of the selected project and issue type
+	issueTypeIDs := map[string]string{}
+	subtaskTypes := map[string]bool{}
+	var epicTypeIDs []string
+	var createFields []models.JiraField
+	var fieldInputs []createFieldInput
+	fieldsBox := container.NewVBox()
+	fieldsRequest := 0
+
+	loadFields := func() {
+		typeID, ok := issueTypeIDs[issueType.Selected]
+		fieldInputs = nil
+		createFields = nil
+		fieldsBox.Objects = nil
+		fieldsBox.Refresh()
+		if !ok || currentProjectKey == "" {
+			return
+		}
+		fieldsRequest++
+		request := fieldsRequest
+		projectKey := currentProjectKey
+		fieldsBox.Add(i18n.BindLabel("backlog.fields_loading"))
+		go func() {
+			fields, err := models.FetchCreateMetaFields(domain, user, token, projectKey, typeID)
+			fyne.Do(func() {
+				if request != fieldsRequest {
+					return
+				}
+				fieldsBox.Objects = nil
+				if err != nil {
+					fieldsBox.Refresh()
+					dialog.ShowError(err, w)
+					return
+				}
+				createFields = fields
+				fieldInputs = buildCreateFieldInputs(w, fields, domain, user, token)
+				if len(fieldInputs) > 0 {
+					fieldsBox.Add(createFieldsForm(fieldInputs))
+				}
+				fieldsBox.Refresh()
+			})
+		}()
+	}
 
-		for _, label := range savedLabels {
-			check := widget.NewCheck(label, func(bool) {})
-			labelChecks[label] = check
-			gridObjects = append(gridObjects, check)
+	parent := newParentPicker(domain, user, token, func() string {
+		return models.ParentPickerJQL(currentProjectKey, subtaskTypes[issueType.Selected], epicTypeIDs)
+	})
+	if prefill.ParentKey != "" {
+		parent.Set(prefill.ParentKey, prefill.ParentSummary)
+	}
+	parentLabel := widget.NewLabel(i18n.T("backlog.parent"))
+	updateParentLabel := func() {
+		key := "backlog.parent"
+		if subtaskTypes[issueType.Selected] {
+			key = "backlog.parent_required"
 		}
+		parentLabel.SetText(i18n.T(key))
+	}
+	i18n.RegisterOnLanguageChange(func() { fyne.Do(updateParentLabel) })
 
-		// container.NewGridWithColumns sorgt für gleichmäßige Spalten und saubere Ausrichtung
-		grid := container.NewGridWithColumns(labelsPerRow, gridObjects...)
-		return container.NewVBox(grid)
+	issueType.OnChanged = func(string) {
+		scheduleAutosave()
+		updateTemplates()
+		updateParentLabel()
+		loadFields()
 	}
-	labelsGrid := container.NewVBox(i18n.BindLabel("backlog.load_types"))
+
+	// likely duplicates of the typed title, the draft can be added to one of them instead
+	var duplicates *duplicatePanel
+	duplicates = newDuplicatePanel(app, domain, user, token, func(key string) {
+		message := fmt.Sprintf(i18n.T("backlog.duplicate_link_confirm"), key)
+		dialog.ShowConfirm(i18n.T("backlog.duplicate_link"), message, func(ok bool) {
+			if !ok {
+				return
+			}
+			comment := titleEntry.Text
+			if strings.TrimSpace(contentEntry.Text) != "" {
+				comment += "\n\n" + contentEntry.Text
+			}
+			go func() {
+				err := models.AddCommentToTicket(domain, user, token, key, comment)
+				fyne.Do(func() {
+					if err != nil {
+						dialog.ShowError(err, w)
+						return
+					}
+					dialog.ShowInformation(i18n.T("backlog.duplicate_linked_title"), fmt.Sprintf(i18n.T("backlog.duplicate_linked"), key), w)
+					titleEntry.SetText("")
+					contentEntry.SetText("")
+					duplicates.Clear()
+				})
+			}()
+		}, w)
+	})
+	titleEntry.OnChanged = func(text string) {
+		duplicates.Search(currentProjectKey, text)
+		scheduleAutosave()
+	}
+	contentEntry.OnChanged = func(string) {
+		scheduleAutosave()
+	}
+	attachments := newAttachmentEditor(app, w, contentEntry, "backlog-description")
+	attachments.OnChanged = func() { scheduleAutosave() }
 
 	createBtn := i18n.BindButton("backlog.create", nil, nil)
 
@@ -94,6 +286,24 @@ func BacklogView(app fyne.App, w fyne.Window, domain, user, token string) fyne.C
 		}()
 	}
 
+	// selectProject selects the project with the given key. It does not have to be a
+	// favourite, e.g. for the parent of a sub-task or a restored draft.
+	selectProject := func(key string) {
+		suffix := fmt.Sprintf("(%s)", key)
+		selected := ""
+		for _, name := range projectSelect.Options {
+			if strings.HasSuffix(name, suffix) {
+				selected = name
+			}
+		}
+		if selected == "" {
+			selected = fmt.Sprintf("%s %s", key, suffix)
+			projectSelect.Options = append(projectSelect.Options, selected)
+		}
+		pendingProject = ""
+		projectSelect.SetSelected(selected)
+	}
+
 	// Load favourite projects
 	go func() {
 		projects, err := models.FetchFavouriteProjects(domain, user, token)
@@ -107,6 +317,10 @@ func BacklogView(app fyne.App, w fyne.Window, domain, user, token string) fyne.C
 		}
 		fyne.Do(func() {
 			projectSelect.Options = projectNames
+			if pendingProject != "" {
+				selectProject(pendingProject)
+				return
+			}
 			if len(projectNames) > 0 {
 				projectSelect.Selected = projectNames[0]
 			}
@@ -114,6 +328,8 @@ func BacklogView(app fyne.App, w fyne.Window, domain, user, token string) fyne.C
 		})
 	}()
 
+	agile := newAgileOptions(prefs, domain, user, token)
+
 	// Load issue types when project changes
 	projectSelect.OnChanged = func(selected string) {
 		start := strings.LastIndex(selected, "(")
@@ -122,6 +338,10 @@ func BacklogView(app fyne.App, w fyne.Window, domain, user, token string) fyne.C
 			return
 		}
 		projectKey := selected[start+1 : end]
+		currentProjectKey = projectKey
+		duplicates.Search(projectKey, titleEntry.Text)
+		agile.LoadProject(projectKey)
+		scheduleAutosave()
 
 		issueType.Options = []string{i18n.T("backlog.load_types")}
 		issueType.Disable()
@@ -135,37 +355,143 @@ func BacklogView(app fyne.App, w fyne.Window, domain, user, token string) fyne.C
 			}
 			fyne.Do(func() {
 				var names []string
+				issueTypeIDs = map[string]string{}
+				subtaskTypes = map[string]bool{}
+				epicTypeIDs = models.EpicTypeIDs(types)
+				preferred := ""
 				for _, t := range types {
 					names = append(names, t.Name)
+					issueTypeIDs[t.Name] = t.ID
+					subtaskTypes[t.Name] = t.Subtask
+					if prefill.Subtask && t.Subtask && preferred == "" && projectKey == prefill.ProjectKey {
+						preferred = t.Name
+					}
+					if t.Name == pendingType {
+						preferred = t.Name
+					}
 				}
+				pendingType = ""
 				issueType.Options = names
 				if len(names) > 0 {
 					issueType.Selected = names[0]
+					if preferred != "" {
+						issueType.Selected = preferred
+					}
 					issueType.Enable()
 				}
 				issueType.Refresh()
+				updateTemplates()
+				updateParentLabel()
+				loadFields()
 			})
 		}()
 
+		labelFavourites = models.LoadLabelFavourites(prefs, projectKey)
+		favouriteLabels = labelFavourites.All()
+		labelsInput.SetTags(labelsInput.Tags())
+		projectLabels = nil
+		labelsRequest++
+		request := labelsRequest
 		go func() {
-			key := fmt.Sprintf("labels_%s", projectKey)
-			saved := app.Preferences().String(key)
-			var savedLabels []string
-			if saved != "" {
-				savedLabels = strings.Split(saved, ",")
+			var counts map[string]int
+			if cache, ok := models.LoadLabelCache(prefs, projectKey); ok {
+				counts = cache.Counts
+			} else {
+				var err error
+				counts, err = models.ScanProjectLabels(domain, user, token, projectKey, nil)
+				if err != nil {
+					fmt.Println("Error loading project labels:", err)
+					return
+				}
+				models.SaveLabelCache(prefs, projectKey, counts)
 			}
+			labels := models.SortedLabels(counts)
+			sort.SliceStable(labels, func(i, j int) bool { return counts[labels[i]] > counts[labels[j]] })
 			fyne.Do(func() {
-				if len(savedLabels) > 0 {
-					grid := updateLabelsGrid(savedLabels)
-					labelsGrid.Objects = []fyne.CanvasObject{grid}
-				} else {
-					labelsGrid.Objects = []fyne.CanvasObject{i18n.BindLabel("backlog.no_labels")}
+				if request == labelsRequest {
+					projectLabels = labels
 				}
-				labelsGrid.Refresh()
 			})
 		}()
 	}
 
+	currentDraft := func() models.Draft {
+		selectedType := issueType.Selected
+		if _, ok := issueTypeIDs[selectedType]; !ok {
+			selectedType = pendingType
+		}
+		project := currentProjectKey
+		if pendingProject != "" {
+			project = pendingProject
+		}
+		return models.Draft{
+			ID:          currentDraftID,
+			Name:        currentDraftName,
+			Project:     project,
+			IssueType:   selectedType,
+			Labels:      labelsInput.Tags(),
+			Title:       titleEntry.Text,
+			Description: contentEntry.Text,
+			ParentKey:   parent.Key(),
+			Attachments: attachments.Paths(),
+		}
+	}
+
+	// applyDraft fills the form; project, type and labels follow once they are loaded
+	applyDraft := func(d models.Draft) {
+		currentDraftID = d.ID
+		currentDraftName = d.Name
+		pendingType = d.IssueType
+		labelsInput.SetTags(d.Labels)
+		titleEntry.SetText(d.Title)
+		contentEntry.SetText(d.Description)
+		attachments.SetPaths(d.Attachments)
+		parent.Set(d.ParentKey, "")
+		if d.Project == "" {
+			return
+		}
+		if len(projectSelect.Options) > 0 && projectSelect.Options[0] != i18n.T("backlog.load_projects") {
+			selectProject(d.Project)
+		} else {
+			pendingProject = d.Project
+		}
+	}
+
+	resetForm := func() {
+		currentDraftID = ""
+		currentDraftName = ""
+		titleEntry.SetText("")
+		contentEntry.SetText("")
+		attachments.SetPaths(nil)
+		if prefill.ParentKey == "" {
+			parent.Set("", "")
+		}
+		labelsInput.SetTags(nil)
+		duplicates.Clear()
+	}
+
+	if prefill.Autosave {
+		var autosaveTimer *time.Timer
+		scheduleAutosave = func() {
+			if autosaveTimer != nil {
+				autosaveTimer.Stop()
+			}
+			autosaveTimer = time.AfterFunc(time.Second, func() {
+				fyne.Do(func() { models.SaveAutosave(prefs, currentDraft()) })
+			})
+		}
+	}
+
+	draftsBtn := widget.NewButtonWithIcon("", theme.FolderIcon(), nil)
+	updateDraftsButton := func() {
+		draftsBtn.SetText(fmt.Sprintf(i18n.T("drafts.button"), len(models.LoadDrafts(prefs))))
+	}
+	updateDraftsButton()
+	i18n.RegisterOnLanguageChange(func() { fyne.Do(updateDraftsButton) })
+	draftsBtn.OnTapped = func() {
+		showDraftsDialog(app, w, domain, user, token, applyDraft, updateDraftsButton)
+	}
+
 	// Create issue
 	createBtn.OnTapped = func() {
 		createBtn.Disable()
@@ -199,39 +525,170 @@ func BacklogView(app fyne.App, w fyne.Window, domain, user, token string) fyne.C
 			}
 
 			var selectedLabels []string
-			for label, check := range labelChecks {
-				if check.Checked {
-					selectedLabels = append(selectedLabels, label)
+			fyne.DoAndWait(func() { selectedLabels = labelsInput.Tags() })
+
+			var fields map[string]interface{}
+			var fieldsErr error
+			fyne.DoAndWait(func() {
+				fields, fieldsErr = collectCreateFields(fieldInputs)
+				if fieldsErr != nil {
+					return
+				}
+				subtask := subtaskTypes[selectedType]
+				parentKey := parent.Key()
+				if parentKey == "" {
+					if subtask {
+						fieldsErr = errors.New(i18n.T("backlog.parent_missing"))
+					}
+					return
 				}
+				for id, value := range models.ParentFields(createFields, parentKey, subtask) {
+					fields[id] = value
+				}
+			})
+			var sprintID int
+			var rankBefore string
+			var form models.Draft
+			var summary, description string
+			var paths []string
+			fyne.DoAndWait(func() {
+				sprintID, rankBefore = agile.Settings()
+				form = currentDraft()
+				summary = titleEntry.Text
+				description = contentEntry.Text
+				paths = attachments.Paths()
+			})
+			if fieldsErr != nil {
+				fyne.Do(func() {
+					createBtn.Enable()
+					dialog.ShowError(fieldsErr, w)
+				})
+				return
 			}
 
-			err := models.CreateJiraIssue(domain, user, token, projectKey, selectedType, titleEntry.Text, contentEntry.Text, selectedLabels)
+			created, err := models.CreateJiraIssue(domain, user, token, projectKey, selectedType, summary, description, selectedLabels, fields)
+
+			// images can only be attached to an existing issue, the description is updated afterwards
+			var uploadErr error
+			if err == nil && len(paths) > 0 {
+				uploadErr = models.AttachInlineImages(domain, user, token, created.Key, description, paths)
+			}
+			var agileErr error
+			if err == nil {
+				agileErr = applyAgileSettings(domain, user, token, created.Key, sprintID, rankBefore)
+			}
+			var afterErr error
+			if err == nil && prefill.AfterCreate != nil {
+				afterErr = prefill.AfterCreate(created, form)
+			}
 			fyne.Do(func() {
 				createBtn.Enable()
 				if err != nil {
 					dialog.ShowError(err, w)
 					return
 				}
-				dialog.ShowInformation(i18n.T("backlog.dialog_created"), i18n.T("backlog.created"), w)
-				titleEntry.SetText("")
-				contentEntry.SetText("")
+				if uploadErr != nil {
+					dialog.ShowError(fmt.Errorf(i18n.T("attachments.upload_failed"), created.Key, uploadErr), w)
+				}
+				if agileErr != nil {
+					dialog.ShowError(fmt.Errorf(i18n.T("agile.apply_failed"), created.Key, agileErr), w)
+				}
+				if afterErr != nil {
+					dialog.ShowError(afterErr, w)
+				}
+				models.AddCreatedHistory(prefs, created.Key, summary)
+				if currentDraftID != "" {
+					if err := models.DeleteDraft(prefs, currentDraftID); err != nil {
+						fmt.Println("Error removing draft:", err)
+					}
+					updateDraftsButton()
+				}
+				if uploadErr == nil {
+					// the images are on the issue now, the created panel clears the form
+					removeStoredAttachments(app, paths, models.AttachmentsInUse(prefs, false))
+				}
+				showCreatedPanel(app, w, domain, created, summary, prefill.OpenIssue, func() {
+					// same project, type, labels, parent and fields, only the text is new
+					currentDraftID = ""
+					currentDraftName = ""
+					titleEntry.SetText("")
+					contentEntry.SetText("")
+					attachments.SetPaths(nil)
+					duplicates.Clear()
+					w.Canvas().Focus(titleEntry)
+				}, func() {
+					resetForm()
+					loadFields()
+				})
 			})
 		}()
 	}
 
+	importBtn := i18n.BindButton("import.open", theme.UploadIcon(), func() {
+		showImportWizard(app, domain, user, token)
+	})
+
+	historyBtn := i18n.BindButton("created.history", theme.HistoryIcon(), func() {
+		showCreatedHistoryDialog(app, w, domain, prefill.OpenIssue)
+	})
+
+	saveDraftBtn := i18n.BindButton("drafts.save", theme.DocumentSaveIcon(), func() {
+		nameEntry := widget.NewEntry()
+		nameEntry.SetText(currentDraftName)
+		nameEntry.SetPlaceHolder(titleEntry.Text)
+		dialog.ShowForm(i18n.T("drafts.save"), i18n.T("settings.save"), i18n.T("bulk.cancel"),
+			[]*widget.FormItem{widget.NewFormItem(i18n.T("drafts.name"), nameEntry)},
+			func(ok bool) {
+				if !ok {
+					return
+				}
+				draft := currentDraft()
+				draft.Name = strings.TrimSpace(nameEntry.Text)
+				if draft.IsEmpty() {
+					dialog.ShowInformation(i18n.T("backlog.error"), i18n.T("backlog.error_fields"), w)
+					return
+				}
+				if _, err := models.UpsertDraft(prefs, draft); err != nil {
+					dialog.ShowError(err, w)
+					return
+				}
+				// start with an empty form, so the next draft of a batch can be written
+				resetForm()
+				updateDraftsButton()
+			}, w)
+	})
+
 	topControls := container.NewVBox(
-		i18n.BindLabel("backlog.header"),
+		container.NewBorder(nil, nil, nil, container.NewHBox(historyBtn, draftsBtn, importBtn), i18n.BindLabel("backlog.header")),
 		i18n.BindLabel("backlog.project"),
 		projectSelect,
 		i18n.BindLabel("backlog.type"),
 		issueType,
+		parentLabel,
+		parent.object,
+		agile.object,
+		i18n.BindLabel("backlog.template"),
+		templateSelect,
 		i18n.BindLabel("backlog.title"),
 		titleEntry,
-		labelsGrid,
+		duplicates.object,
+		i18n.BindLabel("backlog.labels"),
+		labelsInput,
+		fieldsBox,
 		i18n.BindLabel("backlog.description"),
 		generateBtn,
+		contentEntry,
+		attachments.object,
 	)
-	createForm := container.NewBorder(topControls, createBtn, nil, nil, contentEntry)
+	createForm := container.NewBorder(nil, container.NewBorder(nil, nil, nil, saveDraftBtn, createBtn), nil, nil, container.NewVScroll(topControls))
+
+	if prefill.Draft != nil {
+		applyDraft(*prefill.Draft)
+	} else if prefill.Autosave {
+		if draft, ok := models.LoadAutosave(prefs); ok {
+			applyDraft(draft)
+		}
+	}
 
 	return createForm
 }
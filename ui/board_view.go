@@ -0,0 +1,475 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// boardColumnWidth is the minimum width of a board column.
+const boardColumnWidth = 220
+
+// Swimlane modes of the board view, stored in the "board_swimlanes" preference.
+const (
+	swimlanesNone = iota
+	swimlanesAssignee
+	swimlanesEpic
+)
+
+// boardView shows the issues of a Scrum or Kanban board in its columns. Cards can be
+// dragged to another column, which performs the matching workflow transition.
+type boardView struct {
+	object fyne.CanvasObject
+
+	w                   fyne.Window
+	prefs               fyne.Preferences
+	domain, user, token string
+	openIssue           func(key string)
+
+	boardSelect *widget.Select
+	laneSelect  *widget.Select
+	status      *widget.Label
+	content     *fyne.Container
+
+	boards  map[string]models.JiraBoard
+	board   models.JiraBoard
+	config  models.BoardConfiguration
+	issues  []models.BoardIssue
+	zones   []*boardZone
+	hovered *boardZone
+	loaded  bool
+	request int
+}
+
+// boardZone is the drop area of a column within a swimlane.
+type boardZone struct {
+	column int
+	bg     *canvas.Rectangle
+	object fyne.CanvasObject
+}
+
+func newBoardView(app fyne.App, w fyne.Window, domain, user, token string, openIssue func(key string)) *boardView {
+	v := &boardView{w: w, prefs: app.Preferences(), domain: domain, user: user, token: token, openIssue: openIssue}
+	v.status = widget.NewLabel("")
+	v.content = container.NewStack()
+	v.boardSelect = widget.NewSelect([]string{}, v.boardChanged)
+	v.boardSelect.PlaceHolder = i18n.T("agile.no_board")
+	v.laneSelect = widget.NewSelect(swimlaneOptions(), func(string) {
+		v.prefs.SetInt("board_swimlanes", v.laneSelect.SelectedIndex())
+		v.render()
+	})
+	v.laneSelect.SetSelectedIndex(v.prefs.IntWithFallback("board_swimlanes", swimlanesNone))
+
+	refreshBtn := widget.NewButtonWithIcon("", theme.ViewRefreshIcon(), v.reload)
+
+	i18n.RegisterOnLanguageChange(func() {
+		fyne.Do(func() {
+			selected := v.laneSelect.SelectedIndex()
+			v.laneSelect.Options = swimlaneOptions()
+			v.laneSelect.SetSelectedIndex(selected)
+		})
+	})
+
+	header := container.NewBorder(nil, nil,
+		container.NewHBox(i18n.BindLabel("agile.board"), v.boardSelect, i18n.BindLabel("board.swimlanes"), v.laneSelect),
+		refreshBtn, v.status)
+	v.object = container.NewBorder(header, nil, nil, nil, v.content)
+	return v
+}
+
+func swimlaneOptions() []string {
+	return []string{i18n.T("board.swimlanes_none"), i18n.T("board.swimlanes_assignee"), i18n.T("board.swimlanes_epic")}
+}
+
+// Load fetches the boards on first use and selects the last shown one.
+func (v *boardView) Load() {
+	if v.loaded {
+		return
+	}
+	v.loaded = true
+	v.status.SetText(i18n.T("board.loading"))
+	go func() {
+		boards, err := models.FetchBoards(v.domain, v.user, v.token, "")
+		fyne.Do(func() {
+			if err != nil {
+				v.loaded = false
+				v.status.SetText(fmt.Sprintf(i18n.T("board.error_load"), err))
+				return
+			}
+			v.status.SetText("")
+			var names []string
+			var selected string
+			v.boards, names, selected = boardOptions(boards, v.prefs.Int("board_last"))
+			v.boardSelect.Options = names
+			if len(names) == 0 {
+				v.status.SetText(i18n.T("board.no_boards"))
+			}
+			if selected != "" {
+				v.boardSelect.SetSelected(selected)
+			} else {
+				v.boardSelect.Refresh()
+			}
+		})
+	}()
+}
+
+// boardOptions names the boards for a select, names are made unique with the board ID.
+// selected is the name of the board with lastID, "" if it is not in the list.
+func boardOptions(boards []models.JiraBoard, lastID int) (byName map[string]models.JiraBoard, names []string, selected string) {
+	byName = map[string]models.JiraBoard{}
+	for _, b := range boards {
+		name := fmt.Sprintf("%s (%s)", b.Name, b.Type)
+		if _, exists := byName[name]; exists {
+			name = fmt.Sprintf("%s (%s, %d)", b.Name, b.Type, b.ID)
+		}
+		byName[name] = b
+		names = append(names, name)
+		if b.ID == lastID {
+			selected = name
+		}
+	}
+	return byName, names, selected
+}
+
+func (v *boardView) boardChanged(name string) {
+	board, ok := v.boards[name]
+	if !ok {
+		return
+	}
+	v.board = board
+	v.prefs.SetInt("board_last", board.ID)
+	v.reload()
+}
+
+// reload fetches column configuration and issues of the selected board.
+func (v *boardView) reload() {
+	if v.board.ID == 0 {
+		return
+	}
+	v.request++
+	request := v.request
+	board := v.board
+	v.status.SetText(i18n.T("board.loading"))
+	go func() {
+		config, err := models.FetchBoardConfiguration(v.domain, v.user, v.token, board.ID)
+		var issues []models.BoardIssue
+		if err == nil {
+			issues, err = models.FetchBoardIssues(v.domain, v.user, v.token, board)
+		}
+		fyne.Do(func() {
+			if request != v.request {
+				return
+			}
+			if err != nil {
+				v.status.SetText(fmt.Sprintf(i18n.T("board.error_load"), err))
+				return
+			}
+			v.config = config
+			v.issues = issues
+			if len(issues) >= models.MaxBoardIssues {
+				v.status.SetText(fmt.Sprintf(i18n.T("board.issue_count_limited"), len(issues)))
+			} else {
+				v.status.SetText(fmt.Sprintf(i18n.T("board.issue_count"), len(issues)))
+			}
+			v.render()
+		})
+	}()
+}
+
+// columnOf returns the index of the column the issue's status is mapped to, or -1.
+func (v *boardView) columnOf(issue models.BoardIssue) int {
+	for i, c := range v.config.Columns {
+		if c.HasStatus(issue.Status.ID) {
+			return i
+		}
+	}
+	return -1
+}
+
+// boardLane is a swimlane with the issues of each column.
+type boardLane struct {
+	title   string
+	columns [][]models.BoardIssue
+}
+
+// lanes groups the issues into swimlanes in rank order; the lane for issues without
+// assignee or epic comes last.
+func (v *boardView) lanes() []*boardLane {
+	mode := v.laneSelect.SelectedIndex()
+	var lanes []*boardLane
+	byKey := map[string]*boardLane{}
+	var rest *boardLane
+	for _, issue := range v.issues {
+		column := v.columnOf(issue)
+		if column < 0 {
+			continue
+		}
+		key, title := "", ""
+		switch mode {
+		case swimlanesAssignee:
+			key, title = issue.Assignee.AccountID, issue.Assignee.DisplayName
+			if key == "" {
+				title = i18n.T("board.unassigned")
+			}
+		case swimlanesEpic:
+			key, title = issue.EpicKey, issue.EpicKey+" – "+issue.EpicName
+			if key == "" {
+				title = i18n.T("board.no_epic")
+			}
+		}
+		lane, ok := byKey[key]
+		if !ok {
+			lane = &boardLane{title: title, columns: make([][]models.BoardIssue, len(v.config.Columns))}
+			byKey[key] = lane
+			if key == "" {
+				rest = lane
+			} else {
+				lanes = append(lanes, lane)
+			}
+		}
+		lane.columns[column] = append(lane.columns[column], issue)
+	}
+	if rest != nil {
+		lanes = append(lanes, rest)
+	}
+	return lanes
+}
+
+// columnCount counts the issues of a column for its WIP limit.
+func (v *boardView) columnCount(column int) int {
+	count := 0
+	for _, issue := range v.issues {
+		if v.columnOf(issue) != column {
+			continue
+		}
+		if v.config.ConstraintType == models.BoardConstraintExclSubtask && issue.IssueType.Subtask {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
+func (v *boardView) render() {
+	v.zones = nil
+	v.hovered = nil
+	columns := len(v.config.Columns)
+	if columns == 0 {
+		v.content.Objects = nil
+		v.content.Refresh()
+		return
+	}
+
+	rows := container.NewVBox(v.columnHeaders())
+	for _, lane := range v.lanes() {
+		if v.laneSelect.SelectedIndex() != swimlanesNone {
+			total := 0
+			for _, issues := range lane.columns {
+				total += len(issues)
+			}
+			title := widget.NewLabelWithStyle(fmt.Sprintf(i18n.T("board.lane_title"), lane.title, total), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			rows.Add(title)
+		}
+		row := container.NewGridWithColumns(columns)
+		for i, issues := range lane.columns {
+			cards := container.NewVBox()
+			for _, issue := range issues {
+				cards.Add(v.newCard(issue))
+			}
+			bg := canvas.NewRectangle(theme.Color(theme.ColorNameInputBackground))
+			bg.CornerRadius = 4
+			bg.SetMinSize(fyne.NewSize(boardColumnWidth, 60))
+			zone := &boardZone{column: i, bg: bg}
+			zone.object = container.NewStack(bg, container.NewPadded(cards))
+			v.zones = append(v.zones, zone)
+			row.Add(zone.object)
+		}
+		rows.Add(row)
+	}
+	v.content.Objects = []fyne.CanvasObject{container.NewScroll(rows)}
+	v.content.Refresh()
+}
+
+// columnHeaders shows name, issue count and WIP limits of each column. Columns above
+// their maximum are tinted red, columns below their minimum yellow.
+func (v *boardView) columnHeaders() fyne.CanvasObject {
+	row := container.NewGridWithColumns(len(v.config.Columns))
+	limits := v.config.ConstraintType != "" && v.config.ConstraintType != models.BoardConstraintNone
+	for i, c := range v.config.Columns {
+		count := v.columnCount(i)
+		text := fmt.Sprintf("%d", count)
+		var tint color.Color = color.Transparent
+		if limits {
+			switch {
+			case c.Max > 0 && c.Min > 0:
+				text = fmt.Sprintf(i18n.T("board.wip_range"), count, c.Min, c.Max)
+			case c.Max > 0:
+				text = fmt.Sprintf(i18n.T("board.wip_max"), count, c.Max)
+			case c.Min > 0:
+				text = fmt.Sprintf(i18n.T("board.wip_min"), count, c.Min)
+			}
+			switch {
+			case c.Max > 0 && count > c.Max:
+				tint = withAlpha(theme.Color(theme.ColorNameError), 0x55)
+			case c.Min > 0 && count < c.Min:
+				tint = withAlpha(theme.Color(theme.ColorNameWarning), 0x55)
+			}
+		}
+		bg := canvas.NewRectangle(tint)
+		bg.CornerRadius = 4
+		name := widget.NewLabelWithStyle(c.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		row.Add(container.NewStack(bg, container.NewBorder(nil, nil, nil, widget.NewLabel(text), name)))
+	}
+	return row
+}
+
+func withAlpha(c color.Color, alpha uint8) color.Color {
+	r, g, b, _ := c.RGBA()
+	return color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: alpha}
+}
+
+// newCard creates a card that opens the issue when tapped and can be dragged to another column.
+func (v *boardView) newCard(issue models.BoardIssue) *issueCard {
+	card := newIssueCard(issue)
+	card.OnTapped = func() { v.openIssue(issue.Key) }
+	card.OnDragged = func(pos fyne.Position) { v.highlight(v.zoneAt(pos)) }
+	card.OnDropped = func(pos fyne.Position) { v.dropped(issue, pos) }
+	return card
+}
+
+// zoneAt returns the drop zone under the absolute position, or nil.
+func (v *boardView) zoneAt(pos fyne.Position) *boardZone {
+	driver := fyne.CurrentApp().Driver()
+	for _, zone := range v.zones {
+		topLeft := driver.AbsolutePositionForObject(zone.object)
+		size := zone.object.Size()
+		if pos.X >= topLeft.X && pos.X < topLeft.X+size.Width && pos.Y >= topLeft.Y && pos.Y < topLeft.Y+size.Height {
+			return zone
+		}
+	}
+	return nil
+}
+
+// highlight marks the zone a card is dragged over.
+func (v *boardView) highlight(zone *boardZone) {
+	if zone == v.hovered {
+		return
+	}
+	if v.hovered != nil {
+		v.hovered.bg.FillColor = theme.Color(theme.ColorNameInputBackground)
+		v.hovered.bg.Refresh()
+	}
+	v.hovered = zone
+	if zone != nil {
+		zone.bg.FillColor = theme.Color(theme.ColorNameHover)
+		zone.bg.Refresh()
+	}
+}
+
+// dropped moves the issue to the column of the zone it was dropped on.
+func (v *boardView) dropped(issue models.BoardIssue, pos fyne.Position) {
+	v.highlight(nil)
+	zone := v.zoneAt(pos)
+	if zone == nil || zone.column == v.columnOf(issue) {
+		return
+	}
+	column := v.config.Columns[zone.column]
+
+	v.status.SetText(fmt.Sprintf(i18n.T("board.moving"), issue.Key, column.Name))
+	go func() {
+		transitions, err := models.FetchTransitionDetails(v.domain, v.user, v.token, issue.Key)
+		var matching []models.JiraTransitionDetails
+		for _, t := range transitions {
+			if column.HasStatus(t.To.ID) {
+				matching = append(matching, t)
+			}
+		}
+		fyne.Do(func() {
+			v.status.SetText("")
+			switch {
+			case err != nil:
+				dialog.ShowError(err, v.w)
+			case len(matching) == 0:
+				dialog.ShowInformation(issue.Key, fmt.Sprintf(i18n.T("board.no_transition"), column.Name), v.w)
+			case len(matching) == 1:
+				v.transition(issue, matching[0])
+			default:
+				v.chooseTransition(issue, matching)
+			}
+		})
+	}()
+}
+
+// chooseTransition asks which transition to use if several lead into the column.
+func (v *boardView) chooseTransition(issue models.BoardIssue, transitions []models.JiraTransitionDetails) {
+	var names []string
+	byName := map[string]models.JiraTransitionDetails{}
+	for _, t := range transitions {
+		name := fmt.Sprintf("%s → %s", t.Name, t.To.Name)
+		names = append(names, name)
+		byName[name] = t
+	}
+	choice := widget.NewRadioGroup(names, nil)
+	choice.SetSelected(names[0])
+	dialog.ShowCustomConfirm(i18n.T("tickets.transition_label"), i18n.T("bulk.apply"), i18n.T("bulk.cancel"), choice, func(ok bool) {
+		if ok {
+			v.transition(issue, byName[choice.Selected])
+		}
+	}, v.w)
+}
+
+// transition performs the transition, asking for the fields of its screen first.
+func (v *boardView) transition(issue models.BoardIssue, t models.JiraTransitionDetails) {
+	var fields []models.JiraField
+	for _, f := range t.Fields {
+		fields = append(fields, f)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].Required != fields[j].Required {
+			return fields[i].Required
+		}
+		return fields[i].Name < fields[j].Name
+	})
+	inputs := buildCreateFieldInputs(v.w, fields, v.domain, v.user, v.token)
+	if len(inputs) == 0 {
+		v.applyTransition(issue, t, nil)
+		return
+	}
+
+	scroll := container.NewVScroll(createFieldsForm(inputs))
+	scroll.SetMinSize(fyne.NewSize(400, 300))
+	dialog.ShowCustomConfirm(fmt.Sprintf("%s: %s", issue.Key, t.Name), i18n.T("bulk.apply"), i18n.T("bulk.cancel"), scroll, func(ok bool) {
+		if !ok {
+			return
+		}
+		values, err := collectCreateFields(inputs)
+		if err != nil {
+			dialog.ShowError(err, v.w)
+			return
+		}
+		v.applyTransition(issue, t, values)
+	}, v.w)
+}
+
+func (v *boardView) applyTransition(issue models.BoardIssue, t models.JiraTransitionDetails, fields map[string]interface{}) {
+	go func() {
+		err := models.TransitionIssueWithFields(v.domain, v.user, v.token, issue.Key, t.ID, fields)
+		fyne.Do(func() {
+			if err != nil {
+				dialog.ShowError(err, v.w)
+				return
+			}
+			v.status.SetText(fmt.Sprintf(i18n.T("tickets.transitioned"), t.To.Name))
+			v.reload()
+		})
+	}()
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// showCloneDialog asks what to copy from the issue and opens a prefilled create form,
+// where target project and type are chosen.
+func showCloneDialog(app fyne.App, w fyne.Window, issue models.JiraIssue, domain, user, token string) {
+	progress := dialog.NewCustomWithoutButtons(i18n.T("clone.title"), widget.NewProgressBarInfinite(), w)
+	progress.Show()
+
+	go func() {
+		source, err := models.FetchCloneSource(domain, user, token, issue.Key)
+		fyne.Do(func() {
+			progress.Hide()
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			showCloneOptions(app, w, source, domain, user, token)
+		})
+	}()
+}
+
+func showCloneOptions(app fyne.App, w fyne.Window, source models.CloneSource, domain, user, token string) {
+	prefs := app.Preferences()
+	options := models.LoadCloneOptions(prefs)
+
+	check := func(key string, count int, value *bool) *widget.Check {
+		c := widget.NewCheck(fmt.Sprintf(i18n.T(key), count), func(checked bool) { *value = checked })
+		c.SetChecked(*value)
+		return c
+	}
+	description := widget.NewCheck(i18n.T("clone.description"), func(checked bool) { options.Description = checked })
+	description.SetChecked(options.Description)
+	linkOriginal := widget.NewCheck(fmt.Sprintf(i18n.T("clone.link_original"), source.Key), func(checked bool) { options.LinkOriginal = checked })
+	linkOriginal.SetChecked(options.LinkOriginal)
+
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf(i18n.T("clone.intro"), source.Key)),
+		description,
+		check("clone.labels", len(source.Fields.Labels), &options.Labels),
+		check("clone.components", len(source.Fields.Components), &options.Components),
+		check("clone.attachments", len(source.Fields.Attachments), &options.Attachments),
+		check("clone.links", len(source.Fields.IssueLinks), &options.Links),
+		check("clone.subtasks", len(source.Fields.Subtasks), &options.Subtasks),
+		linkOriginal,
+	)
+
+	dialog.ShowCustomConfirm(i18n.T("clone.title"), i18n.T("clone.continue"), i18n.T("bulk.cancel"), content, func(ok bool) {
+		if !ok {
+			return
+		}
+		models.SaveCloneOptions(prefs, options)
+		showCloneWindow(app, source, options, domain, user, token)
+	}, w)
+}
+
+// showCloneWindow opens the create form prefilled with the copied fields. Everything the
+// form does not cover is copied once the clone is created.
+func showCloneWindow(app fyne.App, source models.CloneSource, options models.CloneOptions, domain, user, token string) {
+	draft := models.Draft{
+		Project:   models.ProjectKeyOf(source.Key),
+		IssueType: source.Fields.IssueType.Name,
+		Title:     fmt.Sprintf(i18n.T("clone.summary"), source.Fields.Summary),
+	}
+	description := ""
+	if options.Description {
+		description = models.ExtractDescriptionText(source.Fields.Description)
+		draft.Description = description
+	}
+	if options.Labels {
+		draft.Labels = source.Fields.Labels
+	}
+
+	win := app.NewWindow(fmt.Sprintf(i18n.T("clone.window_title"), source.Key))
+	win.SetContent(BacklogViewWithPrefill(app, win, domain, user, token, BacklogPrefill{
+		ProjectKey: draft.Project,
+		Draft:      &draft,
+		AfterCreate: func(created models.CreatedIssue, form models.Draft) error {
+			var errs []error
+			// the form only edits plain text, keep the original formatting if it wasn't changed
+			if options.Description && description != "" && form.Description == description && len(form.Attachments) == 0 {
+				var adf map[string]interface{}
+				err := json.Unmarshal(source.Fields.Description, &adf)
+				if err == nil {
+					err = models.UpdateIssueDescription(domain, user, token, created.Key, adf)
+				}
+				if err != nil {
+					errs = append(errs, fmt.Errorf(i18n.T("clone.description_failed"), err))
+				}
+			}
+			if err := models.CopyCloneExtras(domain, user, token, source, options, created.Key); err != nil {
+				errs = append(errs, err)
+			}
+			if err := errors.Join(errs...); err != nil {
+				return fmt.Errorf(i18n.T("clone.copy_failed"), created.Key, err)
+			}
+			return nil
+		},
+	}))
+	win.Resize(fyne.NewSize(600, 700))
+	win.Show()
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/helper"
+	"github.com/scramb/backlog-manager/internal/i18n"
+)
+
+// paletteItem is a single entry of the command palette.
+type paletteItem struct {
+	Title    string
+	Subtitle string
+	Icon     fyne.Resource
+	Run      func()
+}
+
+// showCommandPalette opens a fuzzy-searchable list of items. The typed text is also
+// offered as issue via openKey if it is an issue key or Jira link.
+func showCommandPalette(w fyne.Window, items []paletteItem, openKey func(key string)) {
+	filtered := items
+	var d dialog.Dialog
+
+	run := func(item paletteItem) {
+		d.Hide()
+		if item.Run != nil {
+			item.Run()
+		}
+	}
+
+	list := widget.NewList(
+		func() int { return len(filtered) },
+		func() fyne.CanvasObject {
+			title := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			subtitle := widget.NewLabel("")
+			subtitle.Importance = widget.LowImportance
+			return container.NewHBox(widget.NewIcon(nil), title, subtitle)
+		},
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			item := filtered[i]
+			box := o.(*fyne.Container)
+			box.Objects[0].(*widget.Icon).SetResource(item.Icon)
+			box.Objects[1].(*widget.Label).SetText(item.Title)
+			box.Objects[2].(*widget.Label).SetText(item.Subtitle)
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		if id >= 0 && id < len(filtered) {
+			run(filtered[id])
+		}
+	}
+
+	search := widget.NewEntry()
+	search.SetPlaceHolder(i18n.T("palette.placeholder"))
+	search.OnChanged = func(query string) {
+		type scored struct {
+			item  paletteItem
+			score int
+		}
+		var matches []scored
+		for _, item := range items {
+			if score, ok := helper.FuzzyScore(query, item.Title+" "+item.Subtitle); ok {
+				matches = append(matches, scored{item, score})
+			}
+		}
+		sort.SliceStable(matches, func(a, b int) bool { return matches[a].score > matches[b].score })
+
+		filtered = nil
+		if key, ok := helper.ParseIssueReference(query); ok && openKey != nil {
+			filtered = append(filtered, paletteItem{
+				Title:    key,
+				Subtitle: i18n.T("palette.open_issue_key"),
+				Icon:     theme.SearchIcon(),
+				Run:      func() { openKey(key) },
+			})
+		}
+		for _, m := range matches {
+			filtered = append(filtered, m.item)
+		}
+		list.UnselectAll()
+		list.Refresh()
+	}
+	search.OnSubmitted = func(string) {
+		if len(filtered) > 0 {
+			run(filtered[0])
+		}
+	}
+
+	content := container.NewBorder(search, nil, nil, nil, list)
+	d = dialog.NewCustom(i18n.T("palette.title"), i18n.T("bulk.close"), content, w)
+	d.Resize(fyne.NewSize(520, 420))
+	d.Show()
+	w.Canvas().Focus(search)
+}
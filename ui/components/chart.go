@@ -0,0 +1,364 @@
+package components
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Space around the plot area for the axis labels.
+const (
+	chartLeft   = 44
+	chartBottom = 24
+	chartTop    = 8
+	chartRight  = 12
+	chartTicks  = 5
+)
+
+// ChartPoint is a value of a line chart. Label is shown when the pointer is near the point.
+type ChartPoint struct {
+	X, Y  float64
+	Label string
+}
+
+// ChartSeries is a line of a LineChart (Points) or the bars of a BarChart (Values, one
+// per category).
+type ChartSeries struct {
+	Name   string
+	Color  color.Color
+	Points []ChartPoint
+	Values []float64
+	// Step draws the line as steps: the value holds until the next point, as in burndowns.
+	Step bool
+}
+
+// LineChart draws series of points over a numeric x axis, e.g. a burndown over time.
+type LineChart struct {
+	widget.BaseWidget
+
+	Series []ChartSeries
+	// XLabel formats values of the x axis. May be nil.
+	XLabel func(x float64) string
+
+	hovering bool
+	pointer  fyne.Position
+}
+
+// NewLineChart creates a chart of the series.
+func NewLineChart(series []ChartSeries, xLabel func(x float64) string) *LineChart {
+	c := &LineChart{Series: series, XLabel: xLabel}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// CreateRenderer implements fyne.Widget.
+func (c *LineChart) CreateRenderer() fyne.WidgetRenderer {
+	return &chartRenderer{draw: c.draw}
+}
+
+// MouseIn implements desktop.Hoverable.
+func (c *LineChart) MouseIn(e *desktop.MouseEvent) { c.MouseMoved(e) }
+
+// MouseMoved implements desktop.Hoverable.
+func (c *LineChart) MouseMoved(e *desktop.MouseEvent) {
+	c.hovering, c.pointer = true, e.Position
+	c.Refresh()
+}
+
+// MouseOut implements desktop.Hoverable.
+func (c *LineChart) MouseOut() {
+	c.hovering = false
+	c.Refresh()
+}
+
+func (c *LineChart) draw(size fyne.Size) []fyne.CanvasObject {
+	minX, maxX, maxY := math.Inf(1), math.Inf(-1), 0.0
+	for _, s := range c.Series {
+		for _, p := range s.Points {
+			minX, maxX, maxY = math.Min(minX, p.X), math.Max(maxX, p.X), math.Max(maxY, p.Y)
+		}
+	}
+	if math.IsInf(minX, 0) {
+		return nil
+	}
+	if maxX == minX {
+		maxX = minX + 1
+	}
+	f := newChartFrame(size, minX, maxX, maxY)
+	objects := f.yAxis()
+
+	// start, middle and end of the x axis
+	for i := 0; i <= 2; i++ {
+		x := minX + (maxX-minX)*float64(i)/2
+		text := strconv.FormatFloat(x, 'f', -1, 64)
+		if c.XLabel != nil {
+			text = c.XLabel(x)
+		}
+		label := chartText(text)
+		pos := f.pos(x, 0)
+		label.Move(fyne.NewPos(pos.X-label.MinSize().Width*float32(i)/2, pos.Y+4))
+		objects = append(objects, label)
+	}
+
+	for _, s := range c.Series {
+		for i := 1; i < len(s.Points); i++ {
+			from, to := f.pos(s.Points[i-1].X, s.Points[i-1].Y), f.pos(s.Points[i].X, s.Points[i].Y)
+			if s.Step {
+				corner := fyne.NewPos(to.X, from.Y)
+				objects = append(objects, chartLine(from, corner, s.Color), chartLine(corner, to, s.Color))
+			} else {
+				objects = append(objects, chartLine(from, to, s.Color))
+			}
+		}
+	}
+
+	if !c.hovering {
+		return objects
+	}
+	// the point closest to the pointer along the x axis, preferring the first series
+	var nearest *ChartPoint
+	var nearestColor color.Color
+	best := float32(math.MaxFloat32)
+	for _, s := range c.Series {
+		for i := range s.Points {
+			p := &s.Points[i]
+			if d := float32(math.Abs(float64(f.pos(p.X, p.Y).X - c.pointer.X))); d < best-0.5 {
+				best, nearest, nearestColor = d, p, s.Color
+			}
+		}
+	}
+	if nearest == nil || best > 40 {
+		return objects
+	}
+	pos := f.pos(nearest.X, nearest.Y)
+	dot := canvas.NewCircle(nearestColor)
+	dot.Resize(fyne.NewSize(8, 8))
+	dot.Move(pos.SubtractXY(4, 4))
+	objects = append(objects, dot)
+	return append(objects, chartTooltip(nearest.Label, pos, size)...)
+}
+
+// BarChart draws grouped bars, one group per category, e.g. committed and completed work
+// per sprint.
+type BarChart struct {
+	widget.BaseWidget
+
+	Categories []string
+	Series     []ChartSeries
+
+	hovering bool
+	pointer  fyne.Position
+}
+
+// NewBarChart creates a chart of the series' values per category.
+func NewBarChart(categories []string, series []ChartSeries) *BarChart {
+	c := &BarChart{Categories: categories, Series: series}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// CreateRenderer implements fyne.Widget.
+func (c *BarChart) CreateRenderer() fyne.WidgetRenderer {
+	return &chartRenderer{draw: c.draw}
+}
+
+// MouseIn implements desktop.Hoverable.
+func (c *BarChart) MouseIn(e *desktop.MouseEvent) { c.MouseMoved(e) }
+
+// MouseMoved implements desktop.Hoverable.
+func (c *BarChart) MouseMoved(e *desktop.MouseEvent) {
+	c.hovering, c.pointer = true, e.Position
+	c.Refresh()
+}
+
+// MouseOut implements desktop.Hoverable.
+func (c *BarChart) MouseOut() {
+	c.hovering = false
+	c.Refresh()
+}
+
+func (c *BarChart) draw(size fyne.Size) []fyne.CanvasObject {
+	if len(c.Categories) == 0 || len(c.Series) == 0 {
+		return nil
+	}
+	maxY := 0.0
+	for _, s := range c.Series {
+		for _, v := range s.Values {
+			maxY = math.Max(maxY, v)
+		}
+	}
+	f := newChartFrame(size, 0, float64(len(c.Categories)), maxY)
+	objects := f.yAxis()
+
+	group := f.width / float32(len(c.Categories))
+	bar := group * 0.7 / float32(len(c.Series))
+	tooltip := ""
+	var tooltipPos fyne.Position
+	for ci, category := range c.Categories {
+		left := f.pos(float64(ci), 0).X + group*0.15
+		label := chartText(category)
+		if label.MinSize().Width > group {
+			label = chartText(truncateChartText(category, group))
+		}
+		label.Move(fyne.NewPos(left+group*0.35-label.MinSize().Width/2, f.origin.Y+4))
+		objects = append(objects, label)
+
+		for si, s := range c.Series {
+			if ci >= len(s.Values) {
+				continue
+			}
+			top := f.pos(0, s.Values[ci]).Y
+			rect := canvas.NewRectangle(s.Color)
+			rect.Move(fyne.NewPos(left+bar*float32(si), top))
+			rect.Resize(fyne.NewSize(bar-1, f.origin.Y-top))
+			objects = append(objects, rect)
+
+			if c.hovering && c.pointer.X >= rect.Position().X && c.pointer.X < rect.Position().X+bar && c.pointer.Y <= f.origin.Y {
+				tooltip = fmt.Sprintf("%s – %s: %s", category, s.Name, strconv.FormatFloat(s.Values[ci], 'f', -1, 64))
+				tooltipPos = fyne.NewPos(rect.Position().X+bar/2, top)
+			}
+		}
+	}
+	if tooltip != "" {
+		objects = append(objects, chartTooltip(tooltip, tooltipPos, size)...)
+	}
+	return objects
+}
+
+func truncateChartText(text string, width float32) string {
+	runes := []rune(text)
+	for len(runes) > 1 {
+		runes = runes[:len(runes)-1]
+		if fyne.MeasureText(string(runes)+"…", theme.TextSize()-3, fyne.TextStyle{}).Width <= width {
+			break
+		}
+	}
+	return string(runes) + "…"
+}
+
+// chartFrame maps values to positions in the plot area.
+type chartFrame struct {
+	origin        fyne.Position
+	width, height float32
+	minX, maxX    float64
+	maxY          float64
+}
+
+func newChartFrame(size fyne.Size, minX, maxX, maxY float64) chartFrame {
+	return chartFrame{
+		origin: fyne.NewPos(chartLeft, size.Height-chartBottom),
+		width:  size.Width - chartLeft - chartRight,
+		height: size.Height - chartBottom - chartTop,
+		minX:   minX,
+		maxX:   maxX,
+		maxY:   niceMax(maxY),
+	}
+}
+
+func (f chartFrame) pos(x, y float64) fyne.Position {
+	return fyne.NewPos(
+		f.origin.X+float32((x-f.minX)/(f.maxX-f.minX))*f.width,
+		f.origin.Y-float32(y/f.maxY)*f.height,
+	)
+}
+
+// yAxis draws the grid lines with their values and the x axis.
+func (f chartFrame) yAxis() []fyne.CanvasObject {
+	var objects []fyne.CanvasObject
+	grid := theme.Color(theme.ColorNameSeparator)
+	for i := 0; i <= chartTicks; i++ {
+		y := f.maxY * float64(i) / chartTicks
+		pos := f.pos(f.minX, y)
+		objects = append(objects, chartLine(pos, fyne.NewPos(f.origin.X+f.width, pos.Y), grid))
+		label := chartText(strconv.FormatFloat(y, 'f', -1, 64))
+		label.Move(fyne.NewPos(chartLeft-6-label.MinSize().Width, pos.Y-label.MinSize().Height/2))
+		objects = append(objects, label)
+	}
+	return objects
+}
+
+// niceMax rounds the maximum up to 1, 2 or 5 times a power of ten so the ticks are even.
+func niceMax(v float64) float64 {
+	if v <= 0 {
+		return chartTicks
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(v/chartTicks)))
+	for _, step := range []float64{1, 2, 5, 10} {
+		if step*magnitude*chartTicks >= v {
+			return step * magnitude * chartTicks
+		}
+	}
+	return v
+}
+
+func chartText(text string) *canvas.Text {
+	t := canvas.NewText(text, theme.Color(theme.ColorNamePlaceHolder))
+	t.TextSize = theme.TextSize() - 3
+	t.Resize(t.MinSize())
+	return t
+}
+
+func chartLine(from, to fyne.Position, c color.Color) *canvas.Line {
+	line := canvas.NewLine(c)
+	line.StrokeWidth = 2
+	line.Position1, line.Position2 = from, to
+	return line
+}
+
+// chartTooltip shows the text above the position, kept inside the chart.
+func chartTooltip(text string, at fyne.Position, size fyne.Size) []fyne.CanvasObject {
+	label := canvas.NewText(text, theme.Color(theme.ColorNameForeground))
+	label.TextSize = theme.TextSize() - 2
+	textSize := label.MinSize()
+	box := fyne.NewSize(textSize.Width+12, textSize.Height+6)
+	pos := fyne.NewPos(at.X-box.Width/2, at.Y-box.Height-8)
+	pos.X = float32(math.Max(0, math.Min(float64(pos.X), float64(size.Width-box.Width))))
+	pos.Y = float32(math.Max(0, float64(pos.Y)))
+
+	bg := canvas.NewRectangle(theme.Color(theme.ColorNameOverlayBackground))
+	bg.StrokeColor = theme.Color(theme.ColorNameSeparator)
+	bg.StrokeWidth = 1
+	bg.CornerRadius = 4
+	bg.Move(pos)
+	bg.Resize(box)
+	label.Move(pos.AddXY(6, 3))
+	label.Resize(textSize)
+	return []fyne.CanvasObject{bg, label}
+}
+
+// chartRenderer redraws the chart's primitives whenever it is resized or refreshed.
+type chartRenderer struct {
+	draw    func(size fyne.Size) []fyne.CanvasObject
+	size    fyne.Size
+	objects []fyne.CanvasObject
+}
+
+func (r *chartRenderer) Layout(size fyne.Size) {
+	r.size = size
+	r.objects = r.draw(size)
+}
+
+// MinSize keeps charts readable in small windows.
+func (r *chartRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(300, 200)
+}
+
+func (r *chartRenderer) Refresh() {
+	r.Layout(r.size)
+	for _, o := range r.objects {
+		o.Refresh()
+	}
+}
+
+func (r *chartRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *chartRenderer) Destroy() {}
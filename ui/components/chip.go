@@ -1,6 +1,9 @@
 package components
 
 import (
+	"fmt"
+	"image/color"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
@@ -26,3 +29,15 @@ func CreateChip(text string) fyne.CanvasObject {
 	box.Resize(fyne.NewSize(chipWidth, chipHeight))
 	return box
 }
+
+// HexColor parses a colour like "#0052cc"; ok is false for other values.
+func HexColor(hex string) (c color.Color, ok bool) {
+	var r, g, b uint8
+	if len(hex) != 7 || hex[0] != '#' {
+		return nil, false
+	}
+	if _, err := fmt.Sscanf(hex[1:], "%02x%02x%02x", &r, &g, &b); err != nil {
+		return nil, false
+	}
+	return color.NRGBA{R: r, G: g, B: b, A: 0xff}, true
+}
@@ -24,3 +24,17 @@ func CollapsibleSection(title string, content fyne.CanvasObject) fyne.CanvasObje
 
 	return container.NewVBox(header, body)
 }
+
+// ExpandCollapsibleSection opens a section created by CollapsibleSection.
+func ExpandCollapsibleSection(section fyne.CanvasObject) {
+	box, ok := section.(*fyne.Container)
+	if !ok || len(box.Objects) < 2 {
+		return
+	}
+	header, ok := box.Objects[0].(*widget.Button)
+	if !ok {
+		return
+	}
+	box.Objects[1].Show()
+	header.SetIcon(theme.MenuDropUpIcon())
+}
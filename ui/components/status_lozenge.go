@@ -0,0 +1,84 @@
+package components
+
+import (
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// StatusLozenge renders a Jira status as a coloured pill, like the Jira web UI does.
+type StatusLozenge struct {
+	widget.BaseWidget
+
+	bg   *canvas.Rectangle
+	text *canvas.Text
+}
+
+// NewStatusLozenge creates an empty lozenge; call SetStatus to fill it.
+func NewStatusLozenge() *StatusLozenge {
+	l := &StatusLozenge{
+		bg:   canvas.NewRectangle(color.Transparent),
+		text: canvas.NewText("", color.White),
+	}
+	l.bg.CornerRadius = 4
+	l.text.TextSize = theme.TextSize() - 3
+	l.text.TextStyle = fyne.TextStyle{Bold: true}
+	l.text.Alignment = fyne.TextAlignCenter
+	l.ExtendBaseWidget(l)
+	return l
+}
+
+// SetStatus updates the text and colour from the status and its category.
+func (l *StatusLozenge) SetStatus(status models.JiraStatus) {
+	bg, fg := StatusCategoryColors(status.StatusCategory)
+	l.bg.FillColor = bg
+	l.text.Color = fg
+	l.text.Text = strings.ToUpper(status.Name)
+	l.Refresh()
+}
+
+func (l *StatusLozenge) CreateRenderer() fyne.WidgetRenderer {
+	content := container.NewStack(l.bg, container.New(&lozengePadding{}, l.text))
+	return widget.NewSimpleRenderer(content)
+}
+
+// StatusCategoryColors maps Jira's status category colour names to background and text colours.
+func StatusCategoryColors(category models.JiraStatusCategory) (color.Color, color.Color) {
+	switch category.ColorName {
+	case "green":
+		return color.NRGBA{R: 0xe3, G: 0xfc, B: 0xef, A: 0xff}, color.NRGBA{R: 0x00, G: 0x66, B: 0x44, A: 0xff}
+	case "yellow", "blue":
+		return color.NRGBA{R: 0xde, G: 0xeb, B: 0xff, A: 0xff}, color.NRGBA{R: 0x07, G: 0x47, B: 0xa6, A: 0xff}
+	case "blue-gray", "blue-grey", "medium-gray":
+		return color.NRGBA{R: 0xdf, G: 0xe1, B: 0xe6, A: 0xff}, color.NRGBA{R: 0x42, G: 0x52, B: 0x6e, A: 0xff}
+	}
+
+	switch category.Key {
+	case "done":
+		return color.NRGBA{R: 0xe3, G: 0xfc, B: 0xef, A: 0xff}, color.NRGBA{R: 0x00, G: 0x66, B: 0x44, A: 0xff}
+	case "indeterminate":
+		return color.NRGBA{R: 0xde, G: 0xeb, B: 0xff, A: 0xff}, color.NRGBA{R: 0x07, G: 0x47, B: 0xa6, A: 0xff}
+	}
+	return theme.Color(theme.ColorNameButton), theme.Color(theme.ColorNameForeground)
+}
+
+// lozengePadding adds horizontal breathing room around the status text.
+type lozengePadding struct{}
+
+func (p *lozengePadding) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	size := objects[0].MinSize()
+	return fyne.NewSize(size.Width+12, size.Height+4)
+}
+
+func (p *lozengePadding) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	for _, o := range objects {
+		o.Resize(size)
+		o.Move(fyne.NewPos(0, 0))
+	}
+}
@@ -0,0 +1,202 @@
+package components
+
+import (
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// maxTagSuggestions limits the suggestions shown below the entry.
+const maxTagSuggestions = 8
+
+// TagInput is an entry that collects tags as removable chips. Typing offers suggestions,
+// Enter or a comma adds the typed text as new tag.
+type TagInput struct {
+	widget.BaseWidget
+
+	// Suggest returns the candidates in the order they are offered. May be nil.
+	Suggest func() []string
+	// Validate rejects a typed tag with an error that is shown below the entry. May be nil.
+	Validate func(tag string) error
+	// OnChanged is called when tags are added or removed.
+	OnChanged func(tags []string)
+	// Color returns the colour of a tag's dot, nil for none. May be nil.
+	Color func(tag string) color.Color
+
+	tags        []string
+	entry       *widget.Entry
+	chips       *fyne.Container
+	suggestions *fyne.Container
+	errorText   *canvas.Text
+	content     fyne.CanvasObject
+}
+
+// NewTagInput creates an empty tag input with the placeholder shown in its entry.
+func NewTagInput(placeholder string) *TagInput {
+	t := &TagInput{
+		entry:       widget.NewEntry(),
+		chips:       container.New(layout.NewRowWrapLayout()),
+		suggestions: container.NewVBox(),
+		errorText:   canvas.NewText("", theme.Color(theme.ColorNameError)),
+	}
+	t.entry.SetPlaceHolder(placeholder)
+	t.errorText.TextSize = theme.TextSize() - 2
+	t.errorText.Hide()
+
+	t.entry.OnChanged = func(text string) {
+		if strings.HasSuffix(text, ",") {
+			t.add(strings.TrimSuffix(text, ","))
+			return
+		}
+		t.setError(nil)
+		t.showSuggestions(text)
+	}
+	t.entry.OnSubmitted = func(text string) {
+		t.add(text)
+	}
+
+	t.content = container.NewVBox(t.chips, t.entry, t.errorText, t.suggestions)
+	t.ExtendBaseWidget(t)
+	return t
+}
+
+// CreateRenderer implements fyne.Widget.
+func (t *TagInput) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(t.content)
+}
+
+// SetPlaceHolder changes the placeholder of the entry, e.g. after a language change.
+func (t *TagInput) SetPlaceHolder(placeholder string) {
+	t.entry.SetPlaceHolder(placeholder)
+}
+
+// Tags returns the chosen tags in the order they were added.
+func (t *TagInput) Tags() []string {
+	return append([]string{}, t.tags...)
+}
+
+// SetTags replaces the chosen tags without validating them.
+func (t *TagInput) SetTags(tags []string) {
+	t.tags = nil
+	for _, tag := range tags {
+		if tag != "" && !t.has(tag) {
+			t.tags = append(t.tags, tag)
+		}
+	}
+	t.refreshChips()
+}
+
+// add validates and adds the typed text, the entry is cleared on success.
+func (t *TagInput) add(text string) {
+	tag := strings.TrimSpace(text)
+	if tag == "" {
+		t.entry.SetText("")
+		return
+	}
+	if t.Validate != nil {
+		if err := t.Validate(tag); err != nil {
+			t.setError(err)
+			return
+		}
+	}
+	t.addTag(tag)
+}
+
+func (t *TagInput) addTag(tag string) {
+	if !t.has(tag) {
+		t.tags = append(t.tags, tag)
+		t.refreshChips()
+	}
+	t.entry.SetText("")
+	t.showSuggestions("")
+}
+
+func (t *TagInput) remove(tag string) {
+	out := t.tags[:0]
+	for _, existing := range t.tags {
+		if existing != tag {
+			out = append(out, existing)
+		}
+	}
+	t.tags = out
+	t.refreshChips()
+}
+
+func (t *TagInput) has(tag string) bool {
+	for _, existing := range t.tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *TagInput) setError(err error) {
+	if err == nil {
+		t.errorText.Hide()
+		return
+	}
+	t.errorText.Text = err.Error()
+	t.errorText.Show()
+	t.errorText.Refresh()
+}
+
+// showSuggestions lists the candidates containing the text, prefix matches first.
+func (t *TagInput) showSuggestions(text string) {
+	t.suggestions.Objects = nil
+	query := strings.ToLower(strings.TrimSpace(text))
+	if query != "" && t.Suggest != nil {
+		var prefix, contains []string
+		for _, candidate := range t.Suggest() {
+			lower := strings.ToLower(candidate)
+			switch {
+			case t.has(candidate):
+			case strings.HasPrefix(lower, query):
+				prefix = append(prefix, candidate)
+			case strings.Contains(lower, query):
+				contains = append(contains, candidate)
+			}
+		}
+		for i, candidate := range append(prefix, contains...) {
+			if i == maxTagSuggestions {
+				break
+			}
+			tag := candidate
+			btn := widget.NewButtonWithIcon(tag, theme.ContentAddIcon(), func() { t.addTag(tag) })
+			btn.Alignment = widget.ButtonAlignLeading
+			btn.Importance = widget.LowImportance
+			t.suggestions.Add(btn)
+		}
+	}
+	t.suggestions.Refresh()
+}
+
+func (t *TagInput) refreshChips() {
+	t.chips.Objects = nil
+	for _, tag := range t.tags {
+		name := tag
+		removeBtn := widget.NewButtonWithIcon("", theme.CancelIcon(), func() { t.remove(name) })
+		removeBtn.Importance = widget.LowImportance
+
+		bg := canvas.NewRectangle(theme.Color(theme.ColorNameButton))
+		bg.CornerRadius = 12
+		row := container.NewHBox(widget.NewLabel(name), removeBtn)
+		if t.Color != nil {
+			if c := t.Color(name); c != nil {
+				dot := canvas.NewCircle(c)
+				row.Objects = append([]fyne.CanvasObject{container.NewCenter(container.NewGridWrap(fyne.NewSize(10, 10), dot))}, row.Objects...)
+			}
+		}
+		t.chips.Add(container.NewStack(bg, row))
+	}
+	t.chips.Refresh()
+	if t.OnChanged != nil {
+		t.OnChanged(t.Tags())
+	}
+}
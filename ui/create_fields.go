@@ -0,0 +1,389 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// builtinCreateFields are rendered by BacklogView itself and skipped by the dynamic form.
+var builtinCreateFields = map[string]bool{
+	"summary":     true,
+	"description": true,
+	"labels":      true,
+	"project":     true,
+	"issuetype":   true,
+	"reporter":    true,
+	"attachment":  true,
+	"issuelinks":  true,
+	"parent":      true,
+}
+
+// createFieldInput is one rendered field of the create screen.
+// value returns the payload value, or nil if nothing was entered.
+type createFieldInput struct {
+	field  models.JiraField
+	object fyne.CanvasObject
+	value  func() (interface{}, error)
+}
+
+// buildCreateFieldInputs renders a widget for every field of the create screen that
+// BacklogView does not handle itself.
+func buildCreateFieldInputs(w fyne.Window, fields []models.JiraField, domain, user, token string) []createFieldInput {
+	var inputs []createFieldInput
+	for _, f := range fields {
+		if builtinCreateFields[f.ID()] {
+			continue
+		}
+		if input, ok := newCreateFieldInput(w, f, domain, user, token); ok {
+			inputs = append(inputs, input)
+		} else if f.Required && !f.HasDefaultValue {
+			// shown so the user knows why creating fails, the value can't be entered here.
+			// Jira fills in required fields with a default value itself.
+			inputs = append(inputs, createFieldInput{
+				field:  f,
+				object: widget.NewLabel(i18n.T("backlog.field_unsupported")),
+				value:  func() (interface{}, error) { return nil, nil },
+			})
+		}
+	}
+	return inputs
+}
+
+// collectCreateFields validates the inputs and returns the field values for CreateJiraIssue.
+func collectCreateFields(inputs []createFieldInput) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	var missing []string
+	for _, in := range inputs {
+		v, err := in.value()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", in.field.Name, err)
+		}
+		if v == nil {
+			// left out, Jira uses the default value
+			if in.field.Required && !in.field.HasDefaultValue {
+				missing = append(missing, in.field.Name)
+			}
+			continue
+		}
+		values[in.field.ID()] = v
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf(i18n.T("backlog.fields_required"), strings.Join(missing, ", "))
+	}
+	return values, nil
+}
+
+// createFieldsForm lays out the inputs, required fields are marked with an asterisk.
+func createFieldsForm(inputs []createFieldInput) fyne.CanvasObject {
+	box := container.NewVBox()
+	for _, in := range inputs {
+		name := in.field.Name
+		if in.field.Required && !in.field.HasDefaultValue {
+			name += " *"
+		}
+		box.Add(widget.NewLabel(name))
+		box.Add(in.object)
+	}
+	return box
+}
+
+func newCreateFieldInput(w fyne.Window, f models.JiraField, domain, user, token string) (createFieldInput, bool) {
+	input := createFieldInput{field: f}
+	schema := f.Schema
+
+	switch {
+	case schema.Type == "option-with-child":
+		input.object, input.value = cascadingSelectInput(f)
+	case schema.Type == "user":
+		input.object, input.value = userPickerInput(w, domain, user, token, false)
+	case schema.Type == "array" && schema.Items == "user":
+		input.object, input.value = userPickerInput(w, domain, user, token, true)
+	case schema.Type == "array" && len(f.AllowedValues) > 0:
+		input.object, input.value = multiSelectInput(f)
+	case schema.Type == "array" && schema.Items == "string":
+		entry := widget.NewEntry()
+		entry.SetPlaceHolder(i18n.T("bulk.labels_placeholder"))
+		input.object = entry
+		input.value = func() (interface{}, error) {
+			values := strings.Fields(entry.Text)
+			if len(values) == 0 {
+				return nil, nil
+			}
+			return values, nil
+		}
+	case len(f.AllowedValues) > 0:
+		input.object, input.value = singleSelectInput(f)
+	case schema.Type == "date":
+		entry := widget.NewEntry()
+		entry.SetPlaceHolder("2006-01-02")
+		input.object = entry
+		input.value = func() (interface{}, error) {
+			text := strings.TrimSpace(entry.Text)
+			if text == "" {
+				return nil, nil
+			}
+			if _, err := time.Parse("2006-01-02", text); err != nil {
+				return nil, errors.New(i18n.T("backlog.field_invalid_date"))
+			}
+			return text, nil
+		}
+	case schema.Type == "datetime":
+		entry := widget.NewEntry()
+		entry.SetPlaceHolder("2006-01-02 15:04")
+		input.object = entry
+		input.value = func() (interface{}, error) {
+			text := strings.TrimSpace(entry.Text)
+			if text == "" {
+				return nil, nil
+			}
+			t, err := time.ParseInLocation("2006-01-02 15:04", text, time.Local)
+			if err != nil {
+				return nil, errors.New(i18n.T("backlog.field_invalid_date"))
+			}
+			return t.Format("2006-01-02T15:04:05.000-0700"), nil
+		}
+	case schema.Type == "number":
+		entry := widget.NewEntry()
+		input.object = entry
+		input.value = func() (interface{}, error) {
+			text := strings.TrimSpace(entry.Text)
+			if text == "" {
+				return nil, nil
+			}
+			n, err := strconv.ParseFloat(strings.ReplaceAll(text, ",", "."), 64)
+			if err != nil {
+				return nil, errors.New(i18n.T("backlog.field_invalid_number"))
+			}
+			return n, nil
+		}
+	case schema.Type == "string":
+		// textarea custom fields and environment are rich text fields
+		richText := strings.HasSuffix(schema.Custom, ":textarea") || schema.System == "environment"
+		var entry *widget.Entry
+		if richText {
+			entry = widget.NewMultiLineEntry()
+			entry.Wrapping = fyne.TextWrapWord
+			entry.SetMinRowsVisible(3)
+		} else {
+			entry = widget.NewEntry()
+		}
+		input.object = entry
+		input.value = func() (interface{}, error) {
+			if strings.TrimSpace(entry.Text) == "" {
+				return nil, nil
+			}
+			if richText {
+				return models.TextToADF(entry.Text), nil
+			}
+			return entry.Text, nil
+		}
+	default:
+		return input, false
+	}
+	return input, true
+}
+
+// singleSelectInput renders a select list for fields with allowed values (priority, options, versions...).
+func singleSelectInput(f models.JiraField) (fyne.CanvasObject, func() (interface{}, error)) {
+	ids := map[string]string{}
+	var options []string
+	for _, v := range f.AllowedValues {
+		ids[v.Label()] = v.ID
+		options = append(options, v.Label())
+	}
+	sel := widget.NewSelect(options, nil)
+	if defaults := f.DefaultIDs(); len(defaults) > 0 {
+		for _, v := range f.AllowedValues {
+			if v.ID == defaults[0] {
+				sel.SetSelected(v.Label())
+			}
+		}
+	}
+	return sel, func() (interface{}, error) {
+		if sel.Selected == "" {
+			return nil, nil
+		}
+		return map[string]string{"id": ids[sel.Selected]}, nil
+	}
+}
+
+// multiSelectInput renders checkboxes for array fields with allowed values (components, versions...).
+func multiSelectInput(f models.JiraField) (fyne.CanvasObject, func() (interface{}, error)) {
+	ids := map[string]string{}
+	var options []string
+	for _, v := range f.AllowedValues {
+		ids[v.Label()] = v.ID
+		options = append(options, v.Label())
+	}
+	group := widget.NewCheckGroup(options, nil)
+	group.Horizontal = true
+	var selected []string
+	for _, id := range f.DefaultIDs() {
+		for _, v := range f.AllowedValues {
+			if v.ID == id {
+				selected = append(selected, v.Label())
+			}
+		}
+	}
+	group.SetSelected(selected)
+	return group, func() (interface{}, error) {
+		if len(group.Selected) == 0 {
+			return nil, nil
+		}
+		var values []map[string]string
+		for _, label := range group.Selected {
+			values = append(values, map[string]string{"id": ids[label]})
+		}
+		return values, nil
+	}
+}
+
+// cascadingSelectInput renders a parent and a child select for cascading select fields.
+func cascadingSelectInput(f models.JiraField) (fyne.CanvasObject, func() (interface{}, error)) {
+	parents := map[string]models.JiraFieldValue{}
+	var options []string
+	for _, v := range f.AllowedValues {
+		parents[v.Label()] = v
+		options = append(options, v.Label())
+	}
+
+	childIDs := map[string]string{}
+	childSelect := widget.NewSelect([]string{}, nil)
+	childSelect.Disable()
+	parentSelect := widget.NewSelect(options, func(selected string) {
+		childIDs = map[string]string{}
+		var children []string
+		for _, c := range parents[selected].Children {
+			childIDs[c.Label()] = c.ID
+			children = append(children, c.Label())
+		}
+		childSelect.Options = children
+		childSelect.ClearSelected()
+		if len(children) > 0 {
+			childSelect.Enable()
+		} else {
+			childSelect.Disable()
+		}
+		childSelect.Refresh()
+	})
+
+	return container.NewGridWithColumns(2, parentSelect, childSelect), func() (interface{}, error) {
+		if parentSelect.Selected == "" {
+			return nil, nil
+		}
+		value := map[string]interface{}{"id": parents[parentSelect.Selected].ID}
+		if childSelect.Selected != "" {
+			value["child"] = map[string]string{"id": childIDs[childSelect.Selected]}
+		}
+		return value, nil
+	}
+}
+
+// pickedUser is a user chosen in a multi-user picker.
+type pickedUser struct {
+	name      string
+	accountID string
+}
+
+// userPickerInput renders a user search. With multi the picked users are collected as
+// removable chips and the field value is a list of users.
+func userPickerInput(w fyne.Window, domain, user, token string, multi bool) (fyne.CanvasObject, func() (interface{}, error)) {
+	userMap := map[string]string{}
+	userSelect := widget.NewSelect([]string{}, nil)
+	userSelect.PlaceHolder = i18n.T("bulk.select_user")
+	userSearch := widget.NewEntry()
+	userSearch.SetPlaceHolder(i18n.T("bulk.user_search_placeholder"))
+	userSearch.OnSubmitted = func(query string) {
+		go func() {
+			users, err := models.SearchUsers(domain, user, token, query)
+			if err != nil {
+				fyne.Do(func() { dialog.ShowError(err, w) })
+				return
+			}
+			var options []string
+			found := map[string]string{}
+			for _, u := range users {
+				name := u.DisplayName
+				if u.Email != "" {
+					name = fmt.Sprintf("%s <%s>", u.DisplayName, u.Email)
+				}
+				found[name] = u.AccountID
+				options = append(options, name)
+			}
+			fyne.Do(func() {
+				userMap = found
+				userSelect.Options = options
+				userSelect.ClearSelected()
+				userSelect.Refresh()
+			})
+		}()
+	}
+
+	search := container.NewGridWithColumns(2, userSearch, userSelect)
+	if !multi {
+		return search, func() (interface{}, error) {
+			if userSelect.Selected == "" {
+				return nil, nil
+			}
+			return map[string]string{"accountId": userMap[userSelect.Selected]}, nil
+		}
+	}
+
+	var picked []pickedUser
+	chips := container.New(layout.NewRowWrapLayout())
+	var refreshChips func()
+	refreshChips = func() {
+		chips.Objects = nil
+		for i, p := range picked {
+			index := i
+			removeBtn := widget.NewButtonWithIcon("", theme.CancelIcon(), func() {
+				picked = append(picked[:index:index], picked[index+1:]...)
+				refreshChips()
+			})
+			removeBtn.Importance = widget.LowImportance
+			bg := canvas.NewRectangle(theme.Color(theme.ColorNameButton))
+			bg.CornerRadius = 12
+			chips.Add(container.NewStack(bg, container.NewHBox(widget.NewLabel(p.name), removeBtn)))
+		}
+		chips.Refresh()
+	}
+	userSelect.OnChanged = func(selected string) {
+		if selected == "" {
+			return
+		}
+		accountID := userMap[selected]
+		for _, p := range picked {
+			if p.accountID == accountID {
+				userSelect.ClearSelected()
+				return
+			}
+		}
+		picked = append(picked, pickedUser{name: selected, accountID: accountID})
+		refreshChips()
+		userSelect.ClearSelected()
+	}
+
+	return container.NewVBox(chips, search), func() (interface{}, error) {
+		if len(picked) == 0 {
+			return nil, nil
+		}
+		value := make([]map[string]string, len(picked))
+		for i, p := range picked {
+			value[i] = map[string]string{"accountId": p.accountID}
+		}
+		return value, nil
+	}
+}
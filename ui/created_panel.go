@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// issueLinkButtons returns the buttons to open an issue and copy its key or link.
+// open may be nil if the issue can't be shown in Jirion from the calling window.
+func issueLinkButtons(app fyne.App, domain, key, summary string, open func(key string), afterOpen func()) []fyne.CanvasObject {
+	link := models.IssueBrowseURL(domain, key)
+	copyText := func(text string) func() {
+		return func() { app.Clipboard().SetContent(text) }
+	}
+
+	buttons := []fyne.CanvasObject{
+		widget.NewButtonWithIcon(i18n.T("created.open_browser"), theme.ComputerIcon(), func() {
+			if u, err := url.Parse(link); err == nil {
+				app.OpenURL(u)
+			}
+		}),
+	}
+	if open != nil {
+		buttons = append(buttons, widget.NewButtonWithIcon(i18n.T("created.open_jirion"), theme.VisibilityIcon(), func() {
+			open(key)
+			if afterOpen != nil {
+				afterOpen()
+			}
+		}))
+	}
+	buttons = append(buttons,
+		widget.NewButtonWithIcon(i18n.T("created.copy_key"), theme.ContentCopyIcon(), copyText(key)),
+		widget.NewButtonWithIcon(i18n.T("created.copy_link"), theme.ContentCopyIcon(), copyText(link)),
+		widget.NewButtonWithIcon(i18n.T("created.copy_markdown"), theme.ContentCopyIcon(), copyText(fmt.Sprintf("[%s: %s](%s)", key, summary, link))),
+	)
+	return buttons
+}
+
+// showCreatedPanel shows the key of a newly created issue with follow-up actions.
+// createAnother keeps the form settings, done is called when the panel is closed otherwise.
+func showCreatedPanel(app fyne.App, w fyne.Window, domain string, created models.CreatedIssue, summary string, open func(key string), createAnother, done func()) {
+	var d *dialog.CustomDialog
+
+	keyLabel := widget.NewLabelWithStyle(created.Key, fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	keyLabel.SizeName = theme.SizeNameHeadingText
+	summaryLabel := widget.NewLabelWithStyle(summary, fyne.TextAlignCenter, fyne.TextStyle{})
+	summaryLabel.Wrapping = fyne.TextWrapWord
+
+	closed := false
+	closeWith := func(cb func()) func() {
+		return func() {
+			if closed {
+				return
+			}
+			closed = true
+			d.Hide()
+			cb()
+		}
+	}
+
+	anotherBtn := i18n.BindButton("created.create_another", theme.ContentAddIcon(), nil)
+	anotherBtn.Importance = widget.HighImportance
+	anotherBtn.OnTapped = closeWith(createAnother)
+	doneBtn := i18n.BindButton("created.done", theme.ConfirmIcon(), closeWith(done))
+
+	content := container.NewVBox(
+		i18n.BindLabel("backlog.created"),
+		keyLabel,
+		summaryLabel,
+		container.NewGridWithColumns(2, issueLinkButtons(app, domain, created.Key, summary, open, closeWith(done))...),
+		widget.NewSeparator(),
+		container.NewHBox(layout.NewSpacer(), doneBtn, anotherBtn),
+	)
+	d = dialog.NewCustomWithoutButtons(i18n.T("backlog.dialog_created"), content, w)
+	d.Show()
+}
+
+// showCreatedHistoryDialog lists the issues created with Jirion.
+func showCreatedHistoryDialog(app fyne.App, w fyne.Window, domain string, open func(key string)) {
+	history := models.LoadCreatedHistory(app.Preferences())
+	var d dialog.Dialog
+
+	var list *widget.List
+	list = widget.NewList(
+		func() int { return len(history) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			entry := history[i]
+			o.(*widget.Label).SetText(fmt.Sprintf("%s  %s  (%s)", entry.Key, entry.Summary, entry.Created.Format("2006-01-02 15:04")))
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		list.UnselectAll()
+		entry := history[id]
+		var actions dialog.Dialog
+		buttons := issueLinkButtons(app, domain, entry.Key, entry.Summary, open, func() {
+			actions.Hide()
+			d.Hide()
+		})
+		actions = dialog.NewCustom(entry.Key, i18n.T("bulk.close"), container.NewVBox(buttons...), w)
+		actions.Show()
+	}
+
+	var content fyne.CanvasObject = list
+	if len(history) == 0 {
+		content = i18n.BindLabel("created.history_empty")
+	}
+	d = dialog.NewCustom(i18n.T("created.history"), i18n.T("bulk.close"), content, w)
+	d.Resize(fyne.NewSize(700, 450))
+	d.Show()
+}
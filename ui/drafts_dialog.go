@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// showDraftsDialog lists the saved drafts. A draft can be loaded into the create form,
+// deleted, or all drafts can be created in Jira at once. onChanged is called whenever
+// the queue was modified.
+func showDraftsDialog(app fyne.App, w fyne.Window, domain, user, token string, load func(models.Draft), onChanged func()) {
+	prefs := app.Preferences()
+	drafts := models.LoadDrafts(prefs)
+	var d dialog.Dialog
+
+	reload := func() {
+		drafts = models.LoadDrafts(prefs)
+		onChanged()
+	}
+
+	var list *widget.List
+	list = widget.NewList(
+		func() int { return len(drafts) },
+		func() fyne.CanvasObject {
+			title := widget.NewLabel("")
+			title.Truncation = fyne.TextTruncateEllipsis
+			loadBtn := widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), nil)
+			deleteBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), nil)
+			return container.NewBorder(nil, nil, nil, container.NewHBox(loadBtn, deleteBtn), title)
+		},
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			draft := drafts[i]
+			row := o.(*fyne.Container)
+			title := row.Objects[0].(*widget.Label)
+			buttons := row.Objects[1].(*fyne.Container)
+
+			title.SetText(fmt.Sprintf("%s  (%s / %s, %s)", draft.DisplayName(), draft.Project, draft.IssueType, draft.Updated.Format("2006-01-02 15:04")))
+			buttons.Objects[0].(*widget.Button).OnTapped = func() {
+				load(draft)
+				d.Hide()
+			}
+			buttons.Objects[1].(*widget.Button).OnTapped = func() {
+				dialog.ShowConfirm(i18n.T("drafts.delete"), draft.DisplayName(), func(ok bool) {
+					if !ok {
+						return
+					}
+					if err := models.DeleteDraft(prefs, draft.ID); err != nil {
+						dialog.ShowError(err, w)
+						return
+					}
+					removeStoredAttachments(app, draft.Attachments, models.AttachmentsInUse(prefs, true))
+					reload()
+					list.Refresh()
+				}, w)
+			}
+		},
+	)
+
+	status := widget.NewLabel("")
+	progress := widget.NewProgressBar()
+	progress.Hide()
+
+	var submitBtn *widget.Button
+	submitBtn = i18n.BindButton("drafts.submit_all", theme.UploadIcon(), func() {
+		if len(drafts) == 0 {
+			return
+		}
+		submitBtn.Disable()
+		queue := append([]models.Draft{}, drafts...)
+		progress.Max = float64(len(queue))
+		progress.SetValue(0)
+		progress.Show()
+
+		go func() {
+			failed := 0
+			var firstErr error
+			for i, draft := range queue {
+				created, err := models.CreateDraftIssue(domain, user, token, draft)
+				if created.Key != "" {
+					// also when only the images failed, so the issue isn't created twice
+					models.AddCreatedHistory(prefs, created.Key, draft.Title)
+					if deleteErr := models.DeleteDraft(prefs, draft.ID); err == nil {
+						err = deleteErr
+					}
+					if err == nil {
+						removeStoredAttachments(app, draft.Attachments, models.AttachmentsInUse(prefs, true))
+					}
+				}
+				if err != nil {
+					failed++
+					if firstErr == nil {
+						firstErr = fmt.Errorf("%s: %w", draft.DisplayName(), err)
+					}
+				}
+				done := i + 1
+				fyne.Do(func() { progress.SetValue(float64(done)) })
+			}
+
+			fyne.Do(func() {
+				reload()
+				list.Refresh()
+				submitBtn.Enable()
+				status.SetText(fmt.Sprintf(i18n.T("drafts.submitted"), len(queue)-failed, failed))
+				if firstErr != nil {
+					dialog.ShowError(firstErr, w)
+				}
+			})
+		}()
+	})
+	submitBtn.Importance = widget.HighImportance
+
+	content := container.NewBorder(
+		i18n.BindLabel("drafts.description"),
+		container.NewVBox(progress, status, submitBtn),
+		nil, nil,
+		list,
+	)
+	d = dialog.NewCustom(i18n.T("drafts.title"), i18n.T("bulk.close"), content, w)
+	d.Resize(fyne.NewSize(600, 450))
+	d.Show()
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+)
+
+type dropTarget struct {
+	id      string
+	object  fyne.CanvasObject
+	handler func([]fyne.URI)
+}
+
+var dropTargets = map[fyne.Window][]dropTarget{}
+
+// registerDropTarget calls handler with the files dropped onto object. Fyne only keeps
+// one drop callback per window, so the targets of a window share it and the drop position
+// decides which one receives the files. A target with the same id replaces the old one,
+// so views that are rebuilt (like the ticket detail view) don't pile up targets.
+func registerDropTarget(w fyne.Window, id string, object fyne.CanvasObject, handler func([]fyne.URI)) {
+	if _, ok := dropTargets[w]; !ok {
+		w.SetOnDropped(func(pos fyne.Position, uris []fyne.URI) {
+			driver := fyne.CurrentApp().Driver()
+			for _, t := range dropTargets[w] {
+				if !t.object.Visible() || driver.CanvasForObject(t.object) == nil {
+					continue
+				}
+				topLeft := driver.AbsolutePositionForObject(t.object)
+				size := t.object.Size()
+				if pos.X >= topLeft.X && pos.Y >= topLeft.Y && pos.X <= topLeft.X+size.Width && pos.Y <= topLeft.Y+size.Height {
+					t.handler(uris)
+					return
+				}
+			}
+		})
+	}
+	targets := dropTargets[w][:0]
+	for _, t := range dropTargets[w] {
+		if t.id != id {
+			targets = append(targets, t)
+		}
+	}
+	dropTargets[w] = append(targets, dropTarget{id: id, object: object, handler: handler})
+}
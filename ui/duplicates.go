@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+	"github.com/scramb/backlog-manager/ui/components"
+)
+
+// duplicateMinTitle is the title length from which the duplicate search starts.
+const duplicateMinTitle = 8
+
+// duplicatePanel lists existing issues that look like the title being typed.
+type duplicatePanel struct {
+	object *fyne.Container
+
+	app                 fyne.App
+	domain, user, token string
+	onLink              func(key string)
+
+	search int
+	timer  *time.Timer
+}
+
+// newDuplicatePanel builds the panel. onLink is called when the user chooses to add
+// the draft to an existing issue instead of creating a new one.
+func newDuplicatePanel(app fyne.App, domain, user, token string, onLink func(key string)) *duplicatePanel {
+	return &duplicatePanel{
+		object: container.NewVBox(),
+		app:    app,
+		domain: domain,
+		user:   user,
+		token:  token,
+		onLink: onLink,
+	}
+}
+
+// Search looks for duplicates of the title once the user stopped typing.
+func (p *duplicatePanel) Search(projectKey, title string) {
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	p.search++
+	current := p.search
+
+	title = strings.TrimSpace(title)
+	if projectKey == "" || len([]rune(title)) < duplicateMinTitle {
+		p.show(nil)
+		return
+	}
+
+	p.timer = time.AfterFunc(searchDebounce, func() {
+		candidates, err := models.FindDuplicates(p.domain, p.user, p.token, projectKey, title)
+		if err != nil {
+			fmt.Println("Error searching duplicates:", err)
+			return
+		}
+		fyne.Do(func() {
+			if current == p.search {
+				p.show(candidates)
+			}
+		})
+	})
+}
+
+// Clear hides the panel and discards running searches.
+func (p *duplicatePanel) Clear() {
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	p.search++
+	p.show(nil)
+}
+
+func (p *duplicatePanel) show(candidates []models.DuplicateCandidate) {
+	p.object.Objects = nil
+	if len(candidates) > 0 {
+		p.object.Add(widget.NewLabelWithStyle(i18n.T("backlog.duplicates"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+	}
+
+	meta := models.CachedJiraMetadata(p.domain)
+	for i, c := range candidates {
+		if i == 5 {
+			break
+		}
+		key := c.Issue.Key
+
+		lozenge := components.NewStatusLozenge()
+		lozenge.SetStatus(meta.Status(c.Issue.Fields.Status))
+
+		summary := widget.NewLabel(fmt.Sprintf("%s  %s", key, c.Issue.Fields.Summary))
+		summary.Truncation = fyne.TextTruncateEllipsis
+
+		openBtn := widget.NewButtonWithIcon("", theme.ComputerIcon(), func() {
+			if u, err := url.Parse(models.IssueBrowseURL(p.domain, key)); err == nil {
+				p.app.OpenURL(u)
+			}
+		})
+		linkBtn := widget.NewButtonWithIcon(i18n.T("backlog.duplicate_link"), theme.MailForwardIcon(), func() {
+			p.onLink(key)
+		})
+		linkBtn.Importance = widget.LowImportance
+
+		p.object.Add(container.NewBorder(nil, nil,
+			container.NewHBox(widget.NewLabel(fmt.Sprintf("%d%%", c.Score)), container.NewCenter(lozenge)),
+			container.NewHBox(layout.NewSpacer(), openBtn, linkBtn),
+			summary,
+		))
+	}
+	p.object.Refresh()
+}
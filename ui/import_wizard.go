@@ -0,0 +1,489 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// importTarget is a field a column can be mapped to.
+type importTarget struct {
+	ID    string
+	Label string
+}
+
+// projectKeyFromOption extracts the key of a "Name (KEY)" project option.
+func projectKeyFromOption(option string) string {
+	start := strings.LastIndex(option, "(")
+	end := strings.LastIndex(option, ")")
+	if start == -1 || end == -1 || start >= end {
+		return ""
+	}
+	return option[start+1 : end]
+}
+
+// showImportWizard opens the bulk import window: choose a file, map its columns to
+// fields, preview and validate the issues, then create them via the bulk API.
+func showImportWizard(app fyne.App, domain, user, token string) {
+	win := app.NewWindow(i18n.T("import.title"))
+	win.Resize(fyne.NewSize(800, 600))
+
+	var (
+		table        models.ImportTable
+		fileName     string
+		projectKey   string
+		defaultType  string
+		issueTypeIDs = map[string]string{}
+		mapping      []string
+		fieldsByType = map[string][]models.JiraField{}
+		issues       []models.ImportIssue
+	)
+
+	var showSource, showMapping, showPreview func()
+
+	setStep := func(title string, content fyne.CanvasObject, buttons ...fyne.CanvasObject) {
+		header := widget.NewLabelWithStyle(title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		footer := container.NewHBox(append([]fyne.CanvasObject{layout.NewSpacer()}, buttons...)...)
+		win.SetContent(container.NewBorder(header, footer, nil, nil, content))
+	}
+
+	saveReport := func(results []models.BulkCreateResult, dryRun bool) {
+		d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			if writer == nil {
+				return
+			}
+			defer writer.Close()
+			if err := models.WriteImportReport(writer, domain, table, issues, results, dryRun); err != nil {
+				dialog.ShowError(err, win)
+			}
+		}, win)
+		d.SetFileName("jirion-import-report.csv")
+		d.Show()
+	}
+
+	// Step 1: file, project and default issue type
+	showSource = func() {
+		fileLabel := widget.NewLabel(i18n.T("import.no_file"))
+		if fileName != "" {
+			fileLabel.SetText(fmt.Sprintf(i18n.T("import.file_rows"), fileName, len(table.Rows)))
+		}
+
+		nextBtn := i18n.BindButton("import.next", theme.NavigateNextIcon(), nil)
+		nextBtn.Importance = widget.HighImportance
+		updateNext := func() {
+			if fileName != "" && projectKey != "" && defaultType != "" {
+				nextBtn.Enable()
+			} else {
+				nextBtn.Disable()
+			}
+		}
+
+		chooseBtn := i18n.BindButton("import.choose_file", theme.FolderOpenIcon(), func() {
+			d := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+				if err != nil {
+					dialog.ShowError(err, win)
+					return
+				}
+				if reader == nil {
+					return
+				}
+				defer reader.Close()
+
+				parsed, err := models.ParseImportFile(reader.URI().Name(), reader)
+				if err != nil {
+					dialog.ShowError(err, win)
+					return
+				}
+				table = parsed
+				fileName = reader.URI().Name()
+				mapping = nil
+				fileLabel.SetText(fmt.Sprintf(i18n.T("import.file_rows"), fileName, len(table.Rows)))
+				updateNext()
+			}, win)
+			d.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".md", ".markdown", ".txt", ".json"}))
+			d.Show()
+		})
+
+		typeSelect := widget.NewSelect([]string{}, func(selected string) {
+			defaultType = selected
+			updateNext()
+		})
+		typeSelect.PlaceHolder = i18n.T("backlog.load_types")
+		typeSelect.Disable()
+
+		loadTypes := func(key string) {
+			go func() {
+				types, err := models.FetchProjectIssueTypes(domain, user, token, key)
+				if err != nil {
+					fyne.Do(func() { dialog.ShowError(err, win) })
+					return
+				}
+				fyne.Do(func() {
+					issueTypeIDs = map[string]string{}
+					var names []string
+					for _, t := range types {
+						if t.Subtask {
+							// sub-tasks need a parent, which the import does not map
+							continue
+						}
+						issueTypeIDs[t.Name] = t.ID
+						names = append(names, t.Name)
+					}
+					typeSelect.Options = names
+					typeSelect.Enable()
+					if _, ok := issueTypeIDs[defaultType]; ok {
+						typeSelect.SetSelected(defaultType)
+					} else if len(names) > 0 {
+						typeSelect.SetSelected(names[0])
+					}
+				})
+			}()
+		}
+
+		projectSelect := widget.NewSelect([]string{}, func(selected string) {
+			key := projectKeyFromOption(selected)
+			if key != projectKey {
+				projectKey = key
+				fieldsByType = map[string][]models.JiraField{}
+				mapping = nil
+			}
+			defaultType = ""
+			typeSelect.ClearSelected()
+			typeSelect.Disable()
+			updateNext()
+			loadTypes(key)
+		})
+		projectSelect.PlaceHolder = i18n.T("backlog.load_projects")
+		go func() {
+			projects, err := models.FetchFavouriteProjects(domain, user, token)
+			if err != nil {
+				fyne.Do(func() { dialog.ShowError(err, win) })
+				return
+			}
+			fyne.Do(func() {
+				var options []string
+				current := ""
+				for _, p := range projects {
+					option := fmt.Sprintf("%s (%s)", p.Name, p.Key)
+					options = append(options, option)
+					if p.Key == projectKey {
+						current = option
+					}
+				}
+				projectSelect.Options = options
+				projectSelect.Refresh()
+				if current != "" {
+					selectedType := defaultType
+					projectSelect.SetSelected(current)
+					defaultType = selectedType
+				}
+			})
+		}()
+
+		nextBtn.OnTapped = showMapping
+		updateNext()
+
+		setStep(i18n.T("import.step_source"), container.NewVBox(
+			i18n.BindLabel("import.formats"),
+			container.NewHBox(chooseBtn, fileLabel),
+			i18n.BindLabel("backlog.project"),
+			projectSelect,
+			i18n.BindLabel("import.default_type"),
+			typeSelect,
+		), nextBtn)
+	}
+
+	// Step 2: map every column to a field of the create screen
+	showMapping = func() {
+		setStep(i18n.T("import.step_mapping"), container.NewCenter(widget.NewProgressBarInfinite()))
+
+		// the wizard state is only touched on the UI thread, the goroutine just fetches
+		key, issueType := projectKey, defaultType
+		cached, ok := fieldsByType[issueType]
+		typeID := issueTypeIDs[issueType]
+		go func() {
+			fields := cached
+			if !ok {
+				var err error
+				fields, err = models.FetchCreateMetaFields(domain, user, token, key, typeID)
+				if err != nil {
+					fyne.Do(func() {
+						dialog.ShowError(err, win)
+						showSource()
+					})
+					return
+				}
+			}
+
+			fyne.Do(func() {
+				if key != projectKey {
+					return
+				}
+				fieldsByType[issueType] = fields
+
+				targets := []importTarget{
+					{models.ImportIgnore, i18n.T("import.ignore")},
+					{models.ImportSummary, i18n.T("import.target_summary")},
+					{models.ImportDescription, i18n.T("import.target_description")},
+					{models.ImportLabels, i18n.T("import.target_labels")},
+					{models.ImportIssueType, i18n.T("import.target_issuetype")},
+				}
+				for _, f := range fields {
+					if builtinCreateFields[f.ID()] {
+						continue
+					}
+					label := f.Name
+					if f.Required {
+						label += " *"
+					}
+					targets = append(targets, importTarget{f.ID(), label})
+				}
+
+				var labels []string
+				byLabel := map[string]string{}
+				for _, t := range targets {
+					labels = append(labels, t.Label)
+					byLabel[t.Label] = t.ID
+				}
+
+				if len(mapping) != len(table.Columns) {
+					mapping = guessImportMapping(table.Columns, targets)
+				}
+
+				grid := container.NewGridWithColumns(3,
+					widget.NewLabelWithStyle(i18n.T("import.column"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+					widget.NewLabelWithStyle(i18n.T("import.sample"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+					widget.NewLabelWithStyle(i18n.T("import.field"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+				)
+				for col, name := range table.Columns {
+					col := col
+					sample := widget.NewLabel(table.Value(0, col))
+					sample.Truncation = fyne.TextTruncateEllipsis
+					sel := widget.NewSelect(labels, func(selected string) {
+						mapping[col] = byLabel[selected]
+					})
+					for _, t := range targets {
+						if t.ID == mapping[col] {
+							sel.SetSelected(t.Label)
+						}
+					}
+					grid.Add(widget.NewLabel(name))
+					grid.Add(sample)
+					grid.Add(sel)
+				}
+
+				backBtn := i18n.BindButton("import.back", theme.NavigateBackIcon(), showSource)
+				nextBtn := i18n.BindButton("import.next", theme.NavigateNextIcon(), showPreview)
+				nextBtn.Importance = widget.HighImportance
+				setStep(i18n.T("import.step_mapping"), container.NewVScroll(grid), backBtn, nextBtn)
+			})
+		}()
+	}
+
+	// Step 3: validate all rows against createmeta, then dry run or import
+	showPreview = func() {
+		setStep(i18n.T("import.step_preview"), container.NewCenter(widget.NewProgressBarInfinite()))
+
+		// copies of the wizard state for the goroutine, fetched fields are stored on the UI thread
+		key, typeName, rows := projectKey, defaultType, table
+		columns := append([]string(nil), mapping...)
+		typeIDs := map[string]string{}
+		for name, id := range issueTypeIDs {
+			typeIDs[name] = id
+		}
+		known := map[string][]models.JiraField{}
+		for name, fields := range fieldsByType {
+			known[name] = fields
+		}
+		go func() {
+			fetched := map[string][]models.JiraField{}
+			var built []models.ImportIssue
+			for row := range rows.Rows {
+				issueType := models.ImportRowIssueType(rows, row, columns, typeName)
+				typeID, ok := typeIDs[issueType]
+				if !ok {
+					iss := models.BuildImportIssue(rows, row, columns, key, issueType, nil)
+					iss.Errors = append(iss.Errors, fmt.Sprintf(i18n.T("import.unknown_type"), issueType))
+					built = append(built, iss)
+					continue
+				}
+				fields, ok := known[issueType]
+				if !ok {
+					var err error
+					fields, err = models.FetchCreateMetaFields(domain, user, token, key, typeID)
+					if err != nil {
+						fyne.Do(func() {
+							dialog.ShowError(err, win)
+							showMapping()
+						})
+						return
+					}
+					known[issueType] = fields
+					fetched[issueType] = fields
+				}
+				built = append(built, models.BuildImportIssue(rows, row, columns, key, issueType, fields))
+			}
+
+			fyne.Do(func() {
+				if key != projectKey {
+					return
+				}
+				for name, fields := range fetched {
+					fieldsByType[name] = fields
+				}
+				issues = built
+				var valid []map[string]interface{}
+				for _, iss := range issues {
+					if len(iss.Errors) == 0 {
+						valid = append(valid, iss.Fields)
+					}
+				}
+
+				list := widget.NewList(
+					func() int { return len(issues) },
+					func() fyne.CanvasObject {
+						return container.NewBorder(nil, nil, widget.NewIcon(nil), nil, widget.NewLabel(""))
+					},
+					func(i widget.ListItemID, o fyne.CanvasObject) {
+						iss := issues[i]
+						row := o.(*fyne.Container)
+						label := row.Objects[0].(*widget.Label)
+						icon := row.Objects[1].(*widget.Icon)
+						text := fmt.Sprintf("%d  [%s]  %s", iss.Row+1, iss.IssueType, iss.Summary)
+						if len(iss.Errors) > 0 {
+							icon.SetResource(theme.ErrorIcon())
+							text += "  –  " + strings.Join(iss.Errors, "; ")
+						} else {
+							icon.SetResource(theme.ConfirmIcon())
+						}
+						label.SetText(text)
+						label.Truncation = fyne.TextTruncateEllipsis
+					},
+				)
+
+				summary := widget.NewLabel(fmt.Sprintf(i18n.T("import.validation_summary"), len(valid), len(issues)-len(valid)))
+				dryRun := i18n.BindCheckbox("import.dry_run")
+				dryRun.SetChecked(true)
+
+				backBtn := i18n.BindButton("import.back", theme.NavigateBackIcon(), showMapping)
+				runBtn := i18n.BindButton("import.run", theme.UploadIcon(), nil)
+				runBtn.Importance = widget.HighImportance
+				runBtn.OnTapped = func() {
+					if dryRun.Checked {
+						saveReport(nil, true)
+						return
+					}
+					if len(valid) == 0 {
+						return
+					}
+					showImportProgress(app, win, domain, user, token, valid, func(results []models.BulkCreateResult) {
+						saveReport(results, false)
+					})
+				}
+
+				setStep(i18n.T("import.step_preview"),
+					container.NewBorder(summary, dryRun, nil, nil, list),
+					backBtn, runBtn)
+			})
+		}()
+	}
+
+	showSource()
+	win.Show()
+}
+
+// showImportProgress creates the issues and shows the created keys once done.
+// saveReport is offered afterwards to write the result report.
+func showImportProgress(app fyne.App, win fyne.Window, domain, user, token string, issues []map[string]interface{}, saveReport func([]models.BulkCreateResult)) {
+	progress := widget.NewProgressBar()
+	progress.Max = float64(len(issues))
+	status := widget.NewLabel(fmt.Sprintf(i18n.T("import.creating"), len(issues)))
+	header := widget.NewLabelWithStyle(i18n.T("import.step_result"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	win.SetContent(container.NewBorder(container.NewVBox(header, status, progress), nil, nil, nil, layout.NewSpacer()))
+
+	go func() {
+		results := models.CreateIssuesBulk(domain, user, token, issues, func(done int) {
+			fyne.Do(func() { progress.SetValue(float64(done)) })
+		})
+
+		fyne.Do(func() {
+			created := 0
+			for i, r := range results {
+				if r.Err == nil {
+					created++
+					summary, _ := issues[i]["summary"].(string)
+					models.AddCreatedHistory(app.Preferences(), r.Key, summary)
+				}
+			}
+			status.SetText(fmt.Sprintf(i18n.T("import.result_summary"), created, len(results)-created))
+
+			list := widget.NewList(
+				func() int { return len(results) },
+				func() fyne.CanvasObject { return widget.NewLabel("") },
+				func(i widget.ListItemID, o fyne.CanvasObject) {
+					r := results[i]
+					text := fmt.Sprintf("%d  %s", i+1, r.Key)
+					if r.Err != nil {
+						text = fmt.Sprintf("%d  %s", i+1, r.Err.Error())
+					}
+					o.(*widget.Label).SetText(text)
+				},
+			)
+			reportBtn := i18n.BindButton("import.save_report", theme.DocumentSaveIcon(), func() {
+				saveReport(results)
+			})
+			closeBtn := i18n.BindButton("bulk.close", nil, win.Close)
+			win.SetContent(container.NewBorder(
+				container.NewVBox(header, status, progress),
+				container.NewHBox(layout.NewSpacer(), reportBtn, closeBtn),
+				nil, nil,
+				list,
+			))
+		})
+	}()
+}
+
+// guessImportMapping maps columns whose name matches a target ID or label.
+func guessImportMapping(columns []string, targets []importTarget) []string {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.TrimSpace(strings.TrimSuffix(s, " *")))
+	}
+	aliases := map[string]string{
+		"title":        models.ImportSummary,
+		"titel":        models.ImportSummary,
+		"name":         models.ImportSummary,
+		"beschreibung": models.ImportDescription,
+		"type":         models.ImportIssueType,
+		"issue type":   models.ImportIssueType,
+		"typ":          models.ImportIssueType,
+	}
+
+	mapping := make([]string, len(columns))
+	for i, col := range columns {
+		name := normalize(col)
+		if id, ok := aliases[name]; ok {
+			mapping[i] = id
+			continue
+		}
+		for _, t := range targets {
+			if t.ID != models.ImportIgnore && (normalize(t.ID) == name || normalize(t.Label) == name) {
+				mapping[i] = t.ID
+				break
+			}
+		}
+	}
+	return mapping
+}
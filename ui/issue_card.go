@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// issueCard shows an issue as a card for the board and planning views. It can be tapped,
+// dragged and, if OnKey is set, focused to be moved with the keyboard.
+type issueCard struct {
+	widget.BaseWidget
+
+	issue models.BoardIssue
+
+	OnTapped       func()
+	OnDoubleTapped func()
+	// OnDragged is called with the absolute pointer position while the card is dragged.
+	OnDragged func(pos fyne.Position)
+	// OnDropped is called with the absolute position the card was released at.
+	OnDropped func(pos fyne.Position)
+	// OnKey handles keys while the card is focused. The card is only focusable if set.
+	OnKey func(key fyne.KeyName)
+
+	bg       *canvas.Rectangle
+	content  fyne.CanvasObject
+	lastPos  fyne.Position
+	dragging bool
+	focused  bool
+}
+
+func newIssueCard(issue models.BoardIssue) *issueCard {
+	c := &issueCard{issue: issue, bg: canvas.NewRectangle(theme.Color(theme.ColorNameBackground))}
+	c.bg.CornerRadius = 4
+	c.bg.StrokeColor = theme.Color(theme.ColorNameSeparator)
+	c.bg.StrokeWidth = 1
+
+	key := widget.NewLabelWithStyle(issue.Key, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	summary := widget.NewLabel(issue.Summary)
+	summary.Wrapping = fyne.TextWrapWord
+	details := issue.IssueType.Name
+	if issue.Priority.Name != "" {
+		details += " · " + issue.Priority.Name
+	}
+	if issue.Assignee.DisplayName != "" {
+		details += " · " + issue.Assignee.DisplayName
+	}
+	meta := canvas.NewText(details, theme.Color(theme.ColorNamePlaceHolder))
+	meta.TextSize = theme.TextSize() - 2
+
+	top := fyne.CanvasObject(key)
+	if issue.Estimate > 0 {
+		top = container.NewBorder(nil, nil, nil, widget.NewLabel(formatEstimate(issue.Estimate)), key)
+	}
+	c.content = container.NewStack(c.bg, container.NewVBox(top, summary, container.NewPadded(meta)))
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// formatEstimate prints estimates without trailing zeros, e.g. "3" or "0.5".
+func formatEstimate(estimate float64) string {
+	return strconv.FormatFloat(estimate, 'f', -1, 64)
+}
+
+// CreateRenderer implements fyne.Widget.
+func (c *issueCard) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(c.content)
+}
+
+func (c *issueCard) updateBackground() {
+	switch {
+	case c.dragging:
+		c.bg.FillColor = theme.Color(theme.ColorNameHover)
+	case c.focused:
+		c.bg.FillColor = theme.Color(theme.ColorNameSelection)
+	default:
+		c.bg.FillColor = theme.Color(theme.ColorNameBackground)
+	}
+	c.bg.Refresh()
+}
+
+// Tapped implements fyne.Tappable. Focusable cards take the focus.
+func (c *issueCard) Tapped(*fyne.PointEvent) {
+	if c.OnKey != nil {
+		if cv := fyne.CurrentApp().Driver().CanvasForObject(c); cv != nil {
+			cv.Focus(c)
+		}
+	}
+	if c.OnTapped != nil {
+		c.OnTapped()
+	}
+}
+
+// DoubleTapped implements fyne.DoubleTappable.
+func (c *issueCard) DoubleTapped(*fyne.PointEvent) {
+	if c.OnDoubleTapped != nil {
+		c.OnDoubleTapped()
+	}
+}
+
+// Dragged implements fyne.Draggable.
+func (c *issueCard) Dragged(e *fyne.DragEvent) {
+	c.lastPos = e.AbsolutePosition
+	if !c.dragging {
+		c.dragging = true
+		c.updateBackground()
+	}
+	if c.OnDragged != nil {
+		c.OnDragged(e.AbsolutePosition)
+	}
+}
+
+// DragEnd implements fyne.Draggable.
+func (c *issueCard) DragEnd() {
+	c.dragging = false
+	c.updateBackground()
+	if c.OnDropped != nil {
+		c.OnDropped(c.lastPos)
+	}
+}
+
+// FocusGained implements fyne.Focusable.
+func (c *issueCard) FocusGained() {
+	c.focused = true
+	c.updateBackground()
+}
+
+// FocusLost implements fyne.Focusable.
+func (c *issueCard) FocusLost() {
+	c.focused = false
+	c.updateBackground()
+}
+
+// TypedRune implements fyne.Focusable.
+func (c *issueCard) TypedRune(rune) {}
+
+// TypedKey implements fyne.Focusable.
+func (c *issueCard) TypedKey(e *fyne.KeyEvent) {
+	if c.OnKey != nil {
+		c.OnKey(e.Name)
+	}
+}
@@ -0,0 +1,18 @@
+package ui
+
+import "fyne.io/fyne/v2"
+
+var foregroundCallbacks []func()
+
+// onEnteredForeground registers cb to run whenever the app comes to the foreground.
+// Fyne only keeps a single lifecycle hook, so all views share this one.
+func onEnteredForeground(app fyne.App, cb func()) {
+	if len(foregroundCallbacks) == 0 {
+		app.Lifecycle().SetOnEnteredForeground(func() {
+			for _, c := range foregroundCallbacks {
+				c()
+			}
+		})
+	}
+	foregroundCallbacks = append(foregroundCallbacks, cb)
+}
@@ -3,19 +3,32 @@ package ui
 import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
 
 	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+	"github.com/scramb/backlog-manager/internal/shortcuts"
 )
 
+// releaseShortcuts removes the shortcuts of the current main window, nil before the first login.
+var releaseShortcuts func()
+
 // showMainApp initializes the main application window after login.
-// It combines all UI views (Backlog, My Tickets, Settings) into tabs.
+// It combines all UI views (Backlog, My Tickets, Settings, Agile) into tabs.
 func ShowMainApp(w fyne.Window, app fyne.App, domain, user, token string) {
 	// Initialize views
 	prefs := app.Preferences()
-	createView := BacklogView(app, w, domain, user, token)
+	// defined below once the tabs exist
+	var openIssueKey func(key string)
+	createView := BacklogViewWithPrefill(app, w, domain, user, token, BacklogPrefill{
+		Autosave:  true,
+		OpenIssue: func(key string) { openIssueKey(key) },
+	})
 	reloadTickets := make(chan bool)
-	ticketsView := TicketsView(app, w, domain, user, token, reloadTickets)
+	ticketCommands := make(chan ticketsCommand, 8)
+	ticketsView := TicketsView(app, w, domain, user, token, reloadTickets, ticketCommands)
 	settingsView := SettingsView(app, w)
+	agileView, loadAgile := AgileView(app, w, domain, user, token, func(key string) { openIssueKey(key) })
 	serviceDeskView := ServiceDeskView(app, w)
 
 	// Build tab container
@@ -23,6 +36,7 @@ func ShowMainApp(w fyne.Window, app fyne.App, domain, user, token string) {
 		container.NewTabItem(i18n.T("tab.create_backlog"), createView),
 		container.NewTabItem(i18n.T("tab.my_tickets"), ticketsView),
 		container.NewTabItem(i18n.T("tab.settings"), settingsView),
+		container.NewTabItem(i18n.T("tab.agile"), agileView),
 	)
 
 	if prefs.Bool("experimental_enabled") {
@@ -34,17 +48,126 @@ func ShowMainApp(w fyne.Window, app fyne.App, domain, user, token string) {
 			tabs.Items[0].Text = i18n.T("tab.create_backlog")
 			tabs.Items[1].Text = i18n.T("tab.my_tickets")
 			tabs.Items[2].Text = i18n.T("tab.settings")
+			tabs.Items[3].Text = i18n.T("tab.agile")
 			tabs.Refresh()
 			w.SetTitle(i18n.T("app.title"))
 		})
 	})
 
 	tabs.OnSelected = func(tab *container.TabItem) {
-		if tab.Text == i18n.T("tab.my_tickets") {
+		switch tab.Text {
+		case i18n.T("tab.my_tickets"):
 			reloadTickets <- true
+		case i18n.T("tab.agile"):
+			loadAgile()
+		}
+	}
+
+	// ticket shortcuts only apply while the tickets tab is visible
+	sendToTickets := func(action string) func() {
+		return func() {
+			if tabs.SelectedIndex() == 1 {
+				ticketCommands <- ticketsCommand{Action: action}
+			}
+		}
+	}
+
+	openIssueKey = func(key string) {
+		tabs.SelectIndex(1)
+		ticketCommands <- ticketsCommand{Action: shortcuts.ActionOpen, Key: key}
+	}
+
+	// changes that were notified, newest first; notifications cannot be clicked
+	var recentChanges []models.ChangeEvent
+	paletteItems := func() []paletteItem {
+		items := []paletteItem{
+			{Title: i18n.T("shortcuts.create"), Icon: theme.ContentAddIcon(), Run: func() { tabs.SelectIndex(0) }},
+			{Title: i18n.T("import.title"), Icon: theme.UploadIcon(), Run: func() { showImportWizard(app, domain, user, token) }},
+			{Title: i18n.T("created.history"), Icon: theme.HistoryIcon(), Run: func() { showCreatedHistoryDialog(app, w, domain, openIssueKey) }},
+			{Title: i18n.T("shortcuts.goto"), Icon: theme.SearchIcon(), Run: func() { showGoToIssueDialog(app, w, openIssueKey) }},
+			{Title: i18n.T("shortcuts.reload"), Icon: theme.ViewRefreshIcon(), Run: func() {
+				tabs.SelectIndex(1)
+				ticketCommands <- ticketsCommand{Action: shortcuts.ActionReload}
+			}},
+			{Title: i18n.T("shortcuts.settings"), Icon: theme.SettingsIcon(), Run: func() { tabs.SelectIndex(2) }},
+			{Title: i18n.T("shortcuts.help"), Icon: theme.HelpIcon(), Run: func() { showShortcutHelp(app, w) }},
+		}
+		for i, tab := range tabs.Items {
+			index := i
+			items = append(items, paletteItem{
+				Title:    tab.Text,
+				Subtitle: i18n.T("palette.switch_view"),
+				Icon:     theme.NavigateNextIcon(),
+				Run:      func() { tabs.SelectIndex(index) },
+			})
+		}
+		for _, ev := range recentChanges {
+			key := ev.Key
+			n := changeNotification(ev)
+			items = append(items, paletteItem{
+				Title:    n.Title,
+				Subtitle: n.Content,
+				Icon:     theme.InfoIcon(),
+				Run:      func() { openIssueKey(key) },
+			})
 		}
+		for _, r := range models.LoadRecentIssues(prefs) {
+			key := r.Key
+			items = append(items, paletteItem{
+				Title:    r.Key,
+				Subtitle: r.Summary,
+				Icon:     theme.HistoryIcon(),
+				Run:      func() { openIssueKey(key) },
+			})
+		}
+		return items
+	}
+
+	handlers := map[string]func(){
+		shortcuts.ActionPalette:    func() { showCommandPalette(w, paletteItems(), openIssueKey) },
+		shortcuts.ActionHelp:       func() { showShortcutHelp(app, w) },
+		shortcuts.ActionGoTo:       func() { showGoToIssueDialog(app, w, openIssueKey) },
+		shortcuts.ActionCreate:     func() { tabs.SelectIndex(0) },
+		shortcuts.ActionSettings:   func() { tabs.SelectIndex(2) },
+		shortcuts.ActionReload:     sendToTickets(shortcuts.ActionReload),
+		shortcuts.ActionNext:       sendToTickets(shortcuts.ActionNext),
+		shortcuts.ActionPrevious:   sendToTickets(shortcuts.ActionPrevious),
+		shortcuts.ActionOpen:       sendToTickets(shortcuts.ActionOpen),
+		shortcuts.ActionBack:       sendToTickets(shortcuts.ActionBack),
+		shortcuts.ActionComment:    sendToTickets(shortcuts.ActionComment),
+		shortcuts.ActionTransition: sendToTickets(shortcuts.ActionTransition),
+	}
+	// a new login replaces the shortcuts and the change listener of the previous one
+	if releaseShortcuts != nil {
+		releaseShortcuts()
+	}
+	unregister := registerShortcuts(app, w, handlers)
+	stopListening := shortcuts.RegisterOnChange(func() {
+		fyne.Do(func() {
+			unregister()
+			unregister = registerShortcuts(app, w, handlers)
+		})
+	})
+	releaseShortcuts = func() {
+		stopListening()
+		unregister()
 	}
 
+	watchClipboard(app, w, openIssueKey)
+	setupSystemTray(app, w, domain, user, token, openIssueKey)
+	startChangePoller(app, domain, user, token, func(events []models.ChangeEvent) {
+		fyne.Do(func() {
+			for _, ev := range events {
+				recentChanges = append([]models.ChangeEvent{ev}, recentChanges...)
+			}
+			if len(recentChanges) > maxRecentChanges {
+				recentChanges = recentChanges[:maxRecentChanges]
+			}
+		})
+	}, func() {
+		ticketCommands <- ticketsCommand{Action: shortcuts.ActionReload}
+	})
+
 	// Set up window
 	w.SetContent(tabs)
 
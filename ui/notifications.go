@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// maxRecentChanges is the number of notified changes kept for the command palette.
+const maxRecentChanges = 20
+
+// startChangePoller periodically checks the user's issues for changes and raises
+// desktop notifications. Interval, event types and quiet hours are read from the
+// preferences on every run, so changes in the settings apply without a restart.
+// Fyne notifications cannot report clicks, so notified changes are handed to
+// onNotified instead, which keeps them for the user to open.
+func startChangePoller(app fyne.App, domain, user, token string, onNotified func(events []models.ChangeEvent), reload func()) {
+	prefs := app.Preferences()
+
+	// without a previous check only changes from now on are reported
+	if _, err := time.Parse(time.RFC3339, prefs.String("poll_last_check")); err != nil {
+		prefs.SetString("poll_last_check", time.Now().Format(time.RFC3339))
+	}
+
+	go func() {
+		accountID := ""
+		for {
+			interval := prefs.IntWithFallback("poll_interval_minutes", 5)
+			if interval < 1 {
+				interval = 1
+			}
+			time.Sleep(time.Duration(interval) * time.Minute)
+
+			if !prefs.BoolWithFallback("poll_enabled", true) {
+				continue
+			}
+
+			if accountID == "" {
+				me, err := models.FetchMyself(domain, user, token)
+				if err != nil {
+					fmt.Println("Error loading current user:", err)
+					continue
+				}
+				accountID = me.AccountID
+			}
+
+			now := time.Now()
+			since, err := time.Parse(time.RFC3339, prefs.String("poll_last_check"))
+			if err != nil {
+				prefs.SetString("poll_last_check", now.Format(time.RFC3339))
+				continue
+			}
+
+			events, err := models.FetchChangesSince(domain, user, token, accountID, since)
+			if err != nil {
+				fmt.Println("Error polling for changes:", err)
+				continue
+			}
+			prefs.SetString("poll_last_check", now.Format(time.RFC3339))
+
+			quiet := prefs.Bool("quiet_hours_enabled") &&
+				models.InQuietHours(now, prefs.StringWithFallback("quiet_hours_start", "22:00"), prefs.StringWithFallback("quiet_hours_end", "07:00"))
+
+			assigned := false
+			var notified []models.ChangeEvent
+			for _, ev := range events {
+				if ev.Kind == models.ChangeAssigned {
+					assigned = true
+				}
+				if quiet || !prefs.BoolWithFallback("notify_"+ev.Kind, true) {
+					continue
+				}
+
+				app.SendNotification(changeNotification(ev))
+				notified = append(notified, ev)
+			}
+			if len(notified) > 0 && onNotified != nil {
+				onNotified(notified)
+			}
+
+			if assigned && reload != nil {
+				reload()
+			}
+		}
+	}()
+}
+
+func changeNotification(ev models.ChangeEvent) *fyne.Notification {
+	var title, content string
+	switch ev.Kind {
+	case models.ChangeAssigned:
+		title = fmt.Sprintf(i18n.T("notify.assigned_title"), ev.Key)
+		content = fmt.Sprintf(i18n.T("notify.assigned_message"), ev.Summary, ev.Author)
+	case models.ChangeComment:
+		title = fmt.Sprintf(i18n.T("notify.comment_title"), ev.Key)
+		content = fmt.Sprintf(i18n.T("notify.comment_message"), ev.Author, ev.Summary)
+	case models.ChangeStatus:
+		title = fmt.Sprintf(i18n.T("notify.status_title"), ev.Key)
+		content = fmt.Sprintf(i18n.T("notify.status_message"), ev.Summary, ev.Detail, ev.Author)
+	}
+	return fyne.NewNotification(title, content)
+}
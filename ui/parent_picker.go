@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/helper"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// searchDebounce is the delay after the last keystroke before a search is sent.
+const searchDebounce = 300 * time.Millisecond
+
+// parentPicker is a search-as-you-type issue picker for the parent of a new issue.
+type parentPicker struct {
+	object   fyne.CanvasObject
+	entry    *widget.Entry
+	results  *fyne.Container
+	selected string
+
+	search int
+	timer  *time.Timer
+
+	// jql restricts the suggestions, it is evaluated on every search
+	jql func() string
+}
+
+func newParentPicker(domain, user, token string, jql func() string) *parentPicker {
+	p := &parentPicker{jql: jql}
+	p.entry = widget.NewEntry()
+	p.entry.SetPlaceHolder(i18n.T("backlog.parent_placeholder"))
+	p.results = container.NewVBox()
+
+	clearBtn := widget.NewButtonWithIcon("", theme.ContentClearIcon(), func() {
+		p.Set("", "")
+	})
+
+	p.entry.OnChanged = func(text string) {
+		p.selected = ""
+		p.cancelSearch()
+		current := p.search
+		query := strings.TrimSpace(text)
+		if query == "" {
+			p.showResults(nil)
+			return
+		}
+		p.timer = time.AfterFunc(searchDebounce, func() {
+			var restriction string
+			fyne.DoAndWait(func() { restriction = p.jql() })
+			issues, err := models.SearchIssuePicker(domain, user, token, query, restriction)
+			if err != nil {
+				fmt.Println("Error searching parent issues:", err)
+				return
+			}
+			fyne.Do(func() {
+				if current == p.search {
+					p.showResults(issues)
+				}
+			})
+		})
+	}
+
+	p.object = container.NewVBox(
+		container.NewBorder(nil, nil, nil, clearBtn, p.entry),
+		p.results,
+	)
+	return p
+}
+
+func (p *parentPicker) showResults(issues []models.IssuePickerResult) {
+	p.results.Objects = nil
+	for i, iss := range issues {
+		if i == 8 {
+			break
+		}
+		result := iss
+		btn := widget.NewButton(fmt.Sprintf("%s  %s", result.Key, result.Summary), func() {
+			p.Set(result.Key, result.Summary)
+		})
+		btn.Alignment = widget.ButtonAlignLeading
+		btn.Importance = widget.LowImportance
+		p.results.Add(btn)
+	}
+	p.results.Refresh()
+}
+
+// cancelSearch stops a pending search and discards results of running ones.
+func (p *parentPicker) cancelSearch() {
+	if p.timer != nil {
+		p.timer.Stop()
+	}
+	p.search++
+}
+
+// Set selects the given parent, an empty key clears the picker.
+func (p *parentPicker) Set(key, summary string) {
+	text := ""
+	if key != "" {
+		text = fmt.Sprintf("%s  %s", key, summary)
+	}
+	// SetText triggers a search, the selection is applied afterwards
+	p.entry.SetText(text)
+	p.cancelSearch()
+	p.showResults(nil)
+	p.selected = key
+}
+
+// Key returns the key of the selected parent. A plain issue key typed by hand counts as well.
+func (p *parentPicker) Key() string {
+	if p.selected != "" {
+		return p.selected
+	}
+	if key, ok := helper.ParseIssueReference(p.entry.Text); ok {
+		return key
+	}
+	return ""
+}
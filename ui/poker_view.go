@@ -0,0 +1,795 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// pokerView hosts or joins a planning poker session with other instances on the local
+// network. The host picks the issues and reveals the votes, everyone votes with the cards
+// of the session's deck.
+type pokerView struct {
+	object fyne.CanvasObject
+
+	app                 fyne.App
+	w                   fyne.Window
+	prefs               fyne.Preferences
+	domain, user, token string
+	openIssue           func(key string)
+
+	screen *fyne.Container
+
+	// start screen
+	start       fyne.CanvasObject
+	nameEntry   *widget.Entry
+	deckSelect  *widget.Select
+	customCards *widget.Entry
+	ipSelect    *widget.Select
+	portEntry   *widget.Entry
+	joinEntry   *widget.Entry
+	startStatus *widget.Label
+
+	// session screen
+	session      fyne.CanvasObject
+	info         *widget.Label
+	copyBtn      *widget.Button
+	leaveBtn     *widget.Button
+	issueList    *widget.List
+	issueButtons *fyne.Container
+	issueTitle   *widget.Label
+	summary      *widget.Label
+	description  *widget.Label
+	openBtn      *widget.Button
+	cards        *fyne.Container
+	participants *fyne.Container
+	result       *widget.Label
+	hostControls *fyne.Container
+	revealBtn    *widget.Button
+	estimate     *widget.Select
+	status       *widget.Label
+
+	current models.PokerSession
+	host    *models.PokerHost // nil if the session was joined
+	state   models.PokerState
+	// fields are the estimation fields of the issues' boards, known to the host only
+	fields       map[string]string
+	generation   int
+	done         chan struct{}
+	disconnected bool
+	loaded       bool
+}
+
+func newPokerView(app fyne.App, w fyne.Window, domain, user, token string, openIssue func(key string)) *pokerView {
+	v := &pokerView{app: app, w: w, prefs: app.Preferences(), domain: domain, user: user, token: token, openIssue: openIssue}
+	v.start = v.buildStart()
+	v.session = v.buildSession()
+	v.screen = container.NewStack(v.start)
+	v.object = v.screen
+	return v
+}
+
+// deckOptions are the names of the built-in decks and the custom deck.
+func pokerDeckOptions() []string {
+	var options []string
+	for _, d := range models.PokerDecks {
+		options = append(options, d.Name)
+	}
+	return append(options, i18n.T("poker.deck_custom"))
+}
+
+func (v *pokerView) buildStart() fyne.CanvasObject {
+	v.nameEntry = widget.NewEntry()
+	v.nameEntry.SetText(v.prefs.String("poker_name"))
+	v.nameEntry.OnChanged = func(name string) { v.prefs.SetString("poker_name", strings.TrimSpace(name)) }
+
+	v.customCards = widget.NewEntry()
+	v.customCards.SetPlaceHolder("1, 2, 3, 5, 8, ?")
+	v.customCards.SetText(strings.Join(models.LoadPokerCustomCards(v.prefs), ", "))
+	v.customCards.OnChanged = func(text string) {
+		models.SavePokerCustomCards(v.prefs, models.ParsePokerCards(text))
+	}
+	v.deckSelect = widget.NewSelect(pokerDeckOptions(), func(string) {
+		v.prefs.SetInt("poker_deck", v.deckSelect.SelectedIndex())
+		if v.deckSelect.SelectedIndex() == len(models.PokerDecks) {
+			v.customCards.Show()
+		} else {
+			v.customCards.Hide()
+		}
+	})
+	v.deckSelect.SetSelectedIndex(min(v.prefs.Int("poker_deck"), len(models.PokerDecks)))
+	i18n.RegisterOnLanguageChange(func() {
+		fyne.Do(func() {
+			selected := v.deckSelect.SelectedIndex()
+			v.deckSelect.Options = pokerDeckOptions()
+			v.deckSelect.SetSelectedIndex(selected)
+		})
+	})
+
+	// the session is only served on the chosen address, not on every interface
+	v.ipSelect = widget.NewSelect([]string{}, func(ip string) { v.prefs.SetString("poker_address", ip) })
+	v.ipSelect.PlaceHolder = i18n.T("poker.no_address")
+	v.loadAddresses()
+
+	v.portEntry = widget.NewEntry()
+	v.portEntry.SetText(strconv.Itoa(v.prefs.IntWithFallback("poker_port", models.PokerDefaultPort)))
+
+	v.joinEntry = widget.NewEntry()
+	v.joinEntry.SetPlaceHolder("ABCDE-FGHIJ-… / 192.168.1.20:47800/SECRET")
+	v.joinEntry.SetText(v.prefs.String("poker_last_join"))
+	v.joinEntry.OnSubmitted = func(string) { v.joinSession() }
+	v.startStatus = widget.NewLabel("")
+	v.startStatus.Wrapping = fyne.TextWrapWord
+
+	hostCard := widget.NewCard("", "", container.NewVBox(
+		i18n.BindLabel("poker.deck"), v.deckSelect, v.customCards,
+		i18n.BindLabel("poker.address"), container.NewBorder(nil, nil, nil,
+			widget.NewButtonWithIcon("", theme.ViewRefreshIcon(), v.loadAddresses), v.ipSelect),
+		i18n.BindLabel("poker.port"), v.portEntry,
+		i18n.BindButton("poker.host", theme.MediaPlayIcon(), v.hostSession),
+	))
+	joinCard := widget.NewCard("", "", container.NewVBox(
+		i18n.BindLabel("poker.join_address"), v.joinEntry,
+		i18n.BindButton("poker.join", theme.LoginIcon(), v.joinSession),
+	))
+	setCardTitles := func() {
+		hostCard.SetTitle(i18n.T("poker.host_title"))
+		joinCard.SetTitle(i18n.T("poker.join_title"))
+	}
+	setCardTitles()
+	i18n.RegisterOnLanguageChange(func() { fyne.Do(setCardTitles) })
+
+	form := container.NewVBox(
+		i18n.BindLabel("poker.name"), v.nameEntry,
+		container.NewGridWithColumns(2, hostCard, joinCard),
+		v.startStatus,
+	)
+	return container.NewVScroll(container.NewPadded(form))
+}
+
+func (v *pokerView) buildSession() fyne.CanvasObject {
+	v.info = widget.NewLabel("")
+	v.info.Wrapping = fyne.TextWrapWord
+	v.copyBtn = widget.NewButtonWithIcon("", theme.ContentCopyIcon(), func() {
+		if v.host != nil {
+			v.app.Clipboard().SetContent(v.host.Code())
+		}
+	})
+	v.leaveBtn = widget.NewButtonWithIcon("", theme.LogoutIcon(), v.leave)
+	i18n.RegisterOnLanguageChange(func() {
+		fyne.Do(func() {
+			if v.current != nil {
+				v.showHeader()
+				v.update(v.state)
+			}
+		})
+	})
+	v.status = widget.NewLabel("")
+	header := container.NewBorder(nil, nil, nil, container.NewHBox(v.copyBtn, v.leaveBtn), v.info)
+
+	v.issueList = widget.NewList(
+		func() int { return len(v.state.Issues) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return container.NewBorder(nil, nil, widget.NewIcon(theme.NavigateNextIcon()), widget.NewLabel(""), label)
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			if id >= len(v.state.Issues) {
+				return
+			}
+			issue := v.state.Issues[id]
+			row := o.(*fyne.Container)
+			row.Objects[0].(*widget.Label).SetText(fmt.Sprintf("%s %s", issue.Key, issue.Summary))
+			if id == v.state.Current {
+				row.Objects[1].Show()
+			} else {
+				row.Objects[1].Hide()
+			}
+			row.Objects[2].(*widget.Label).SetText(issue.Estimate)
+		},
+	)
+	v.issueList.OnSelected = func(id widget.ListItemID) {
+		v.issueList.UnselectAll()
+		if v.host != nil && id != v.state.Current && id < len(v.state.Issues) {
+			v.selectIssue(v.state.Issues[id])
+		}
+	}
+	v.issueButtons = container.NewHBox(
+		i18n.BindButton("poker.add_issues", theme.ContentAddIcon(), v.showAddIssues),
+		i18n.BindButton("poker.remove_issue", theme.ContentRemoveIcon(), func() {
+			if issue, ok := v.state.CurrentIssue(); ok && v.host != nil {
+				v.host.RemoveIssue(issue.Key)
+			}
+		}),
+	)
+	issues := container.NewBorder(i18n.BindLabel("poker.issues"), v.issueButtons, nil, nil, v.issueList)
+
+	v.issueTitle = widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	v.summary = widget.NewLabel("")
+	v.summary.Wrapping = fyne.TextWrapWord
+	v.description = widget.NewLabel("")
+	v.description.Wrapping = fyne.TextWrapWord
+	v.openBtn = widget.NewButtonWithIcon("", theme.SearchIcon(), func() {
+		if issue, ok := v.state.CurrentIssue(); ok {
+			v.openIssue(issue.Key)
+		}
+	})
+	v.cards = container.NewGridWrap(fyne.NewSize(56, 72))
+	v.result = widget.NewLabel("")
+	v.result.Wrapping = fyne.TextWrapWord
+
+	v.revealBtn = i18n.BindButton("poker.reveal", theme.VisibilityIcon(), func() {
+		if v.host != nil {
+			v.host.Reveal()
+		}
+	})
+	revoteBtn := i18n.BindButton("poker.revote", theme.ViewRefreshIcon(), func() {
+		if v.host != nil {
+			v.host.Revote()
+		}
+	})
+	v.estimate = widget.NewSelect([]string{}, nil)
+	saveBtn := i18n.BindButton("poker.save_estimate", theme.DocumentSaveIcon(), v.saveEstimate)
+	v.hostControls = container.NewHBox(v.revealBtn, revoteBtn, i18n.BindLabel("poker.estimate"), v.estimate, saveBtn)
+
+	detail := container.NewBorder(
+		container.NewVBox(container.NewBorder(nil, nil, nil, v.openBtn, v.issueTitle), v.summary),
+		container.NewVBox(widget.NewSeparator(), v.cards, v.result, v.hostControls),
+		nil, nil,
+		container.NewVScroll(v.description))
+
+	v.participants = container.NewVBox()
+	people := container.NewBorder(i18n.BindLabel("poker.participants"), nil, nil, nil, container.NewVScroll(v.participants))
+
+	split := container.NewHSplit(issues, container.NewHSplit(detail, people))
+	split.Offset = 0.3
+	split.Trailing.(*container.Split).Offset = 0.75
+	return container.NewBorder(header, v.status, nil, nil, split)
+}
+
+// Load looks up the user's display name as default name in sessions.
+func (v *pokerView) Load() {
+	if v.loaded || strings.TrimSpace(v.nameEntry.Text) != "" {
+		return
+	}
+	v.loaded = true
+	go func() {
+		me, err := models.FetchMyself(v.domain, v.user, v.token)
+		if err != nil {
+			fmt.Println("Error loading user:", err)
+			return
+		}
+		fyne.Do(func() {
+			if strings.TrimSpace(v.nameEntry.Text) == "" {
+				v.nameEntry.SetText(me.DisplayName)
+			}
+		})
+	}()
+}
+
+// loadAddresses offers the local network addresses to host on, the last used one first.
+func (v *pokerView) loadAddresses() {
+	var options []string
+	for _, ip := range models.PokerLANAddresses() {
+		options = append(options, ip.String())
+	}
+	v.ipSelect.Options = options
+	v.ipSelect.ClearSelected()
+	for _, o := range options {
+		if o == v.prefs.String("poker_address") {
+			v.ipSelect.SetSelected(o)
+		}
+	}
+	if v.ipSelect.Selected == "" && len(options) > 0 {
+		v.ipSelect.SetSelected(options[0])
+	}
+}
+
+// name returns the entered name, showing an error if there is none or it is too long.
+func (v *pokerView) name() (string, bool) {
+	name := strings.TrimSpace(v.nameEntry.Text)
+	switch {
+	case name == "":
+		v.startStatus.SetText(i18n.T("poker.name_required"))
+		return "", false
+	case len([]rune(name)) > models.MaxPokerNameLength:
+		v.startStatus.SetText(fmt.Sprintf(i18n.T("poker.name_too_long"), models.MaxPokerNameLength))
+		return "", false
+	}
+	return name, true
+}
+
+func (v *pokerView) hostSession() {
+	name, ok := v.name()
+	if !ok {
+		return
+	}
+	deck := models.PokerDeck{Name: i18n.T("poker.deck_custom"), Cards: models.ParsePokerCards(v.customCards.Text)}
+	if i := v.deckSelect.SelectedIndex(); i >= 0 && i < len(models.PokerDecks) {
+		deck = models.PokerDecks[i]
+	}
+	if len(deck.Cards) < 2 {
+		v.startStatus.SetText(i18n.T("poker.deck_too_small"))
+		return
+	}
+	port, err := strconv.Atoi(strings.TrimSpace(v.portEntry.Text))
+	if err != nil || port <= 0 || port > 65535 {
+		v.startStatus.SetText(i18n.T("poker.invalid_port"))
+		return
+	}
+	ip := net.ParseIP(v.ipSelect.Selected)
+	if ip == nil {
+		v.startStatus.SetText(i18n.T("poker.no_address"))
+		return
+	}
+	v.prefs.SetInt("poker_port", port)
+	host, err := models.NewPokerHost(name, deck, ip, port)
+	if err != nil {
+		v.startStatus.SetText(fmt.Sprintf(i18n.T("poker.error_host"), err))
+		return
+	}
+	v.fields = map[string]string{}
+	v.enter(host, host)
+}
+
+func (v *pokerView) joinSession() {
+	name, ok := v.name()
+	if !ok {
+		return
+	}
+	address := strings.TrimSpace(v.joinEntry.Text)
+	v.prefs.SetString("poker_last_join", address)
+	v.startStatus.SetText(i18n.T("poker.joining"))
+	go func() {
+		client, err := models.JoinPokerSession(address, name)
+		fyne.Do(func() {
+			if err != nil {
+				v.startStatus.SetText(fmt.Sprintf(i18n.T("poker.error_join"), err))
+				return
+			}
+			v.enter(client, nil)
+		})
+	}()
+}
+
+// enter shows the session screen and follows the session's changes until it is left.
+func (v *pokerView) enter(session models.PokerSession, host *models.PokerHost) {
+	v.current, v.host = session, host
+	v.state = models.PokerState{Current: -1}
+	v.generation++
+	generation := v.generation
+	v.done = make(chan struct{})
+	done := v.done
+	v.startStatus.SetText("")
+	v.status.SetText("")
+
+	v.showHeader()
+	if host != nil {
+		v.copyBtn.Show()
+		v.issueButtons.Show()
+		v.hostControls.Show()
+	} else {
+		v.copyBtn.Hide()
+		v.issueButtons.Hide()
+		v.hostControls.Hide()
+	}
+	v.update(v.state)
+	v.screen.Objects = []fyne.CanvasObject{v.session}
+	v.screen.Refresh()
+
+	go func() {
+		version := 0
+		for {
+			state, err := session.State(version)
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if err != nil {
+				fyne.Do(func() {
+					if generation == v.generation {
+						v.disconnected = true
+						v.status.SetText(fmt.Sprintf(i18n.T("poker.error_connection"), err))
+					}
+				})
+				time.Sleep(2 * time.Second)
+				continue
+			}
+			version = state.Version
+			fyne.Do(func() {
+				if generation == v.generation {
+					v.update(state)
+				}
+			})
+			if state.Closed {
+				return
+			}
+		}
+	}()
+}
+
+// showHeader shows how to join a hosted session, or which session was joined.
+func (v *pokerView) showHeader() {
+	if v.host == nil {
+		v.info.SetText(fmt.Sprintf(i18n.T("poker.joined"), v.joinEntry.Text))
+		v.leaveBtn.SetText(i18n.T("poker.leave"))
+		return
+	}
+	v.info.SetText(fmt.Sprintf(i18n.T("poker.hosting"), v.host.Code(), v.host.Address()+"/"+v.host.Secret()))
+	v.leaveBtn.SetText(i18n.T("poker.end"))
+}
+
+// update shows a new state of the session.
+func (v *pokerView) update(state models.PokerState) {
+	if state.Closed && v.host == nil {
+		v.exit()
+		dialog.ShowInformation(i18n.T("poker.ended_title"), i18n.T("poker.ended"), v.w)
+		return
+	}
+	if v.disconnected {
+		v.disconnected = false
+		v.status.SetText("")
+	}
+	v.state = state
+	v.issueList.Refresh()
+
+	issue, ok := state.CurrentIssue()
+	if ok {
+		v.issueTitle.SetText(fmt.Sprintf("%s · %s", issue.Key, issue.Type))
+		v.summary.SetText(issue.Summary)
+		v.description.SetText(issue.Description)
+		v.openBtn.Show()
+	} else {
+		v.issueTitle.SetText(i18n.T("poker.no_issue"))
+		v.summary.SetText("")
+		v.description.SetText("")
+		v.openBtn.Hide()
+	}
+
+	var own string
+	for _, p := range state.Participants {
+		if p.You {
+			own = p.Vote
+		}
+	}
+	v.cards.Objects = nil
+	for _, c := range state.Deck.Cards {
+		card := c
+		btn := widget.NewButton(card, func() {
+			if card == own {
+				v.vote("")
+			} else {
+				v.vote(card)
+			}
+		})
+		if card == own {
+			btn.Importance = widget.HighImportance
+		}
+		if !ok || state.Revealed {
+			btn.Disable()
+		}
+		v.cards.Objects = append(v.cards.Objects, btn)
+	}
+	v.cards.Refresh()
+
+	v.participants.Objects = nil
+	for _, p := range state.Participants {
+		vote := "…"
+		switch {
+		case p.Vote != "" && (state.Revealed || p.You):
+			vote = p.Vote
+		case p.Voted:
+			vote = "✓"
+		}
+		name := p.Name
+		if p.Host {
+			name = fmt.Sprintf(i18n.T("poker.host_name"), p.Name)
+		}
+		v.participants.Add(widget.NewLabel(fmt.Sprintf("%s — %s", name, vote)))
+	}
+
+	v.result.SetText("")
+	v.revealBtn.Enable()
+	v.estimate.Options = state.Deck.Cards
+	if !ok {
+		v.revealBtn.Disable()
+		v.estimate.ClearSelected()
+		return
+	}
+	if !state.Revealed {
+		voted := 0
+		for _, p := range state.Participants {
+			if p.Voted {
+				voted++
+			}
+		}
+		v.result.SetText(fmt.Sprintf(i18n.T("poker.voted"), voted, len(state.Participants)))
+		v.estimate.SetSelected(issue.Estimate)
+		return
+	}
+	v.revealBtn.Disable()
+	cards, counts, suggestion := state.Votes()
+	var parts []string
+	for _, c := range cards {
+		parts = append(parts, fmt.Sprintf("%s × %d", c, counts[c]))
+	}
+	if len(cards) == 1 {
+		v.result.SetText(fmt.Sprintf(i18n.T("poker.consensus"), cards[0]))
+	} else {
+		v.result.SetText(fmt.Sprintf(i18n.T("poker.result"), strings.Join(parts, ", ")))
+	}
+	v.estimate.SetSelected(suggestion)
+}
+
+func (v *pokerView) vote(card string) {
+	session := v.current
+	go func() {
+		if err := session.Vote(card); err != nil {
+			fyne.Do(func() { v.status.SetText(fmt.Sprintf(i18n.T("poker.error_vote"), err)) })
+		}
+	}()
+}
+
+// selectIssue starts the vote on an issue and shares its description once it is loaded.
+func (v *pokerView) selectIssue(issue models.PokerIssue) {
+	host := v.host
+	host.Select(issue.Key)
+	if issue.Description != "" {
+		return
+	}
+	go func() {
+		full, err := models.FetchIssue(v.domain, v.user, v.token, issue.Key)
+		if err != nil {
+			fmt.Println("Error loading issue:", err)
+			return
+		}
+		host.SetDescription(issue.Key, models.ExtractDescriptionText(full.Fields.Description))
+	}()
+}
+
+// saveEstimate writes the chosen card to the estimation field of the current issue and
+// moves on to the next issue without estimate.
+func (v *pokerView) saveEstimate() {
+	issue, ok := v.state.CurrentIssue()
+	if !ok || v.host == nil || v.estimate.Selected == "" {
+		return
+	}
+	card := v.estimate.Selected
+	value, numbered := models.PokerCardValue(card)
+	if !numbered {
+		dialog.ShowError(fmt.Errorf(i18n.T("poker.card_not_numeric"), card), v.w)
+		return
+	}
+	field := v.fields[issue.Key]
+	if field == "" {
+		dialog.ShowError(errors.New(i18n.T("poker.no_estimation_field")), v.w)
+		return
+	}
+	host := v.host
+	current := v.state.Current
+	v.status.SetText(i18n.T("board.loading"))
+	go func() {
+		err := models.SetIssueEstimate(v.domain, v.user, v.token, issue.Key, field, value)
+		fyne.Do(func() {
+			if err != nil {
+				v.status.SetText("")
+				dialog.ShowError(err, v.w)
+				return
+			}
+			v.status.SetText(fmt.Sprintf(i18n.T("poker.saved"), issue.Key, card))
+			host.SetEstimate(issue.Key, card)
+			for i := 1; i < len(v.state.Issues); i++ {
+				next := (current + i) % len(v.state.Issues)
+				if v.state.Issues[next].Estimate == "" {
+					v.selectIssue(v.state.Issues[next])
+					return
+				}
+			}
+		})
+	}()
+}
+
+// showAddIssues lets the host pick issues of a sprint or backlog for the session.
+func (v *pokerView) showAddIssues() {
+	host := v.host
+	var boards map[string]models.JiraBoard
+	var sprints map[string]int
+	var issues []models.BoardIssue
+	var config models.BoardConfiguration
+	request := 0
+
+	status := widget.NewLabel(i18n.T("board.loading"))
+	check := widget.NewCheckGroup(nil, nil)
+	issueKeys := map[string]string{}
+	sprintSelect := widget.NewSelect([]string{}, nil)
+	boardSelect := widget.NewSelect([]string{}, nil)
+	boardSelect.PlaceHolder = i18n.T("agile.no_board")
+
+	loadIssues := func() {
+		board, ok := boards[boardSelect.Selected]
+		sprintID, sprintOK := sprints[sprintSelect.Selected]
+		if !ok || !sprintOK {
+			return
+		}
+		request++
+		current := request
+		status.SetText(i18n.T("board.loading"))
+		go func() {
+			cfg, err := models.FetchBoardConfiguration(v.domain, v.user, v.token, board.ID)
+			var loaded []models.BoardIssue
+			if err == nil {
+				loaded, err = models.FetchSprintIssues(v.domain, v.user, v.token, board.ID, sprintID, cfg.EstimationField)
+			}
+			fyne.Do(func() {
+				if current != request {
+					return
+				}
+				if err != nil {
+					status.SetText(fmt.Sprintf(i18n.T("board.error_load"), err))
+					return
+				}
+				config, issues = cfg, loaded
+				issueKeys = map[string]string{}
+				var options, unestimated []string
+				for _, issue := range models.PokerIssuesOf(issues) {
+					option := fmt.Sprintf("%s – %s", issue.Key, issue.Summary)
+					if issue.Estimate != "" {
+						option += fmt.Sprintf(" (%s)", issue.Estimate)
+					} else {
+						unestimated = append(unestimated, option)
+					}
+					issueKeys[option] = issue.Key
+					options = append(options, option)
+				}
+				check.Options = options
+				check.SetSelected(unestimated)
+				status.SetText(fmt.Sprintf(i18n.T("poker.issue_count"), len(options)))
+				if config.EstimationField == "" {
+					status.SetText(i18n.T("poker.no_estimation_field"))
+				}
+			})
+		}()
+	}
+	sprintSelect.OnChanged = func(string) { loadIssues() }
+	boardSelect.OnChanged = func(name string) {
+		board, ok := boards[name]
+		if !ok {
+			return
+		}
+		v.prefs.SetInt("poker_board", board.ID)
+		request++
+		current := request
+		go func() {
+			list, err := models.FetchSprints(v.domain, v.user, v.token, board.ID, models.SprintActive, models.SprintFuture)
+			if err != nil {
+				// Kanban boards have no sprints, only the backlog
+				fmt.Println("Error loading sprints:", err)
+			}
+			fyne.Do(func() {
+				if current != request {
+					return
+				}
+				backlog := i18n.T("agile.backlog")
+				sprints = map[string]int{backlog: 0}
+				options := []string{backlog}
+				selected := backlog
+				for _, s := range list {
+					name := s.Name
+					if s.State == models.SprintActive {
+						name = fmt.Sprintf(i18n.T("agile.sprint_active"), s.Name)
+					} else if selected == backlog {
+						// the next sprint is usually the one to estimate
+						selected = name
+					}
+					sprints[name] = s.ID
+					options = append(options, name)
+				}
+				sprintSelect.Options = options
+				sprintSelect.SetSelected(selected)
+			})
+		}()
+	}
+
+	go func() {
+		list, err := models.FetchBoards(v.domain, v.user, v.token, "")
+		fyne.Do(func() {
+			if err != nil {
+				status.SetText(fmt.Sprintf(i18n.T("board.error_load"), err))
+				return
+			}
+			var names []string
+			var selected string
+			boards, names, selected = boardOptions(list, v.prefs.Int("poker_board"))
+			boardSelect.Options = names
+			status.SetText("")
+			if selected == "" && len(names) > 0 {
+				selected = names[0]
+			}
+			boardSelect.SetSelected(selected)
+		})
+	}()
+
+	scroll := container.NewVScroll(check)
+	scroll.SetMinSize(fyne.NewSize(500, 300))
+	content := container.NewBorder(
+		container.NewVBox(
+			container.NewGridWithColumns(2,
+				container.NewVBox(i18n.BindLabel("agile.board"), boardSelect),
+				container.NewVBox(i18n.BindLabel("agile.sprint"), sprintSelect)),
+			status),
+		nil, nil, nil, scroll)
+	dialog.ShowCustomConfirm(i18n.T("poker.add_issues"), i18n.T("bulk.apply"), i18n.T("bulk.cancel"), content, func(ok bool) {
+		if !ok {
+			return
+		}
+		selected := map[string]bool{}
+		for _, option := range check.Selected {
+			selected[issueKeys[option]] = true
+		}
+		var picked []models.BoardIssue
+		for _, issue := range issues {
+			if selected[issue.Key] {
+				picked = append(picked, issue)
+				v.fields[issue.Key] = config.EstimationField
+			}
+		}
+		added := models.PokerIssuesOf(picked)
+		host.AddIssues(added)
+		if v.state.Current >= 0 || len(added) == 0 {
+			return
+		}
+		// start with the first added issue that has no estimate yet
+		for _, issue := range added {
+			if issue.Estimate == "" {
+				v.selectIssue(issue)
+				return
+			}
+		}
+		v.selectIssue(added[0])
+	}, v.w)
+}
+
+// leave leaves the session, or ends it for everyone if this instance hosts it.
+func (v *pokerView) leave() {
+	message := i18n.T("poker.leave_confirm")
+	if v.host != nil {
+		message = i18n.T("poker.end_confirm")
+	}
+	dialog.ShowConfirm(v.leaveBtn.Text, message, func(ok bool) {
+		if !ok {
+			return
+		}
+		session := v.current
+		v.exit()
+		go func() {
+			if err := session.Leave(); err != nil {
+				fmt.Println("Error leaving poker session:", err)
+			}
+		}()
+	}, v.w)
+}
+
+// exit stops following the session and shows the start screen again.
+func (v *pokerView) exit() {
+	if v.done != nil {
+		close(v.done)
+		v.done = nil
+	}
+	v.generation++
+	v.current, v.host = nil, nil
+	v.screen.Objects = []fyne.CanvasObject{v.start}
+	v.screen.Refresh()
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/helper"
+	"github.com/scramb/backlog-manager/internal/i18n"
+)
+
+// showGoToIssueDialog asks for an issue key or Jira link and opens the issue.
+// The entry is prefilled if the clipboard already holds an issue reference.
+func showGoToIssueDialog(app fyne.App, w fyne.Window, open func(key string)) {
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder(i18n.T("goto.placeholder"))
+	if _, ok := helper.ParseIssueReference(app.Clipboard().Content()); ok {
+		entry.SetText(app.Clipboard().Content())
+	}
+
+	var d dialog.Dialog
+	submit := func() {
+		key, ok := helper.ParseIssueReference(entry.Text)
+		if !ok {
+			dialog.ShowError(fmt.Errorf(i18n.T("goto.invalid"), entry.Text), w)
+			return
+		}
+		d.Hide()
+		open(key)
+	}
+	entry.OnSubmitted = func(string) { submit() }
+
+	d = dialog.NewCustomConfirm(i18n.T("goto.title"), i18n.T("goto.open"), i18n.T("bulk.cancel"), entry, func(ok bool) {
+		if ok {
+			submit()
+		}
+	}, w)
+	d.Resize(fyne.NewSize(480, 160))
+	d.Show()
+	w.Canvas().Focus(entry)
+}
+
+// watchClipboard offers to open an issue whenever the app comes to the foreground
+// with a new Jira link or issue key in the clipboard.
+func watchClipboard(app fyne.App, w fyne.Window, open func(key string)) {
+	prefs := app.Preferences()
+	lastSeen := ""
+	// ignore whatever was in the clipboard when Jirion started
+	if key, ok := helper.ParseIssueReference(app.Clipboard().Content()); ok {
+		lastSeen = key
+	}
+
+	onEnteredForeground(app, func() {
+		if !prefs.BoolWithFallback("clipboard_detection", true) {
+			return
+		}
+		key, ok := helper.ParseIssueReference(app.Clipboard().Content())
+		if !ok || key == lastSeen {
+			return
+		}
+		lastSeen = key
+		dialog.ShowConfirm(i18n.T("goto.clipboard_title"), fmt.Sprintf(i18n.T("goto.clipboard_message"), key), func(ok bool) {
+			if ok {
+				open(key)
+			}
+		}, w)
+	})
+}
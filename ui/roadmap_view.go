@@ -0,0 +1,536 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// Geometry of the roadmap timeline.
+const (
+	roadmapRowHeight  = 34
+	roadmapHeader     = 36
+	roadmapLabelWidth = 260
+	roadmapEdge       = 8
+	// roadmapDefaultDays is the length of epics that have only one of their dates.
+	roadmapDefaultDays = 14
+)
+
+// roadmapZooms are the pixels per day of the zoom levels: quarters, months, weeks.
+var roadmapZooms = []float32{3, 8, 20}
+
+// roadmapView shows the epics of the selected favourite projects on a timeline. Bars can
+// be dragged to move an epic or, at their edges, to change its start or due date.
+type roadmapView struct {
+	object fyne.CanvasObject
+
+	w                   fyne.Window
+	prefs               fyne.Preferences
+	domain, user, token string
+	openIssue           func(key string)
+
+	zoomSelect *widget.Select
+	status     *widget.Label
+	content    *fyne.Container
+	scroll     *container.Scroll
+
+	projects []models.JiraProject
+	selected []string
+	roadmap  models.Roadmap
+	origin   time.Time
+	perDay   float32
+	loaded   bool
+	request  int
+}
+
+func newRoadmapView(app fyne.App, w fyne.Window, domain, user, token string, openIssue func(key string)) *roadmapView {
+	v := &roadmapView{w: w, prefs: app.Preferences(), domain: domain, user: user, token: token, openIssue: openIssue}
+	v.status = widget.NewLabel("")
+	v.content = container.NewWithoutLayout()
+	v.scroll = container.NewScroll(v.content)
+
+	v.zoomSelect = widget.NewSelect(roadmapZoomOptions(), func(string) {
+		v.prefs.SetInt("roadmap_zoom", v.zoomSelect.SelectedIndex())
+		v.render()
+	})
+	v.zoomSelect.SetSelectedIndex(v.prefs.IntWithFallback("roadmap_zoom", 1))
+	i18n.RegisterOnLanguageChange(func() {
+		fyne.Do(func() {
+			selected := v.zoomSelect.SelectedIndex()
+			v.zoomSelect.Options = roadmapZoomOptions()
+			v.zoomSelect.SetSelectedIndex(selected)
+		})
+	})
+
+	projectsBtn := i18n.BindButton("roadmap.projects", theme.ListIcon(), v.showProjects)
+	refreshBtn := widget.NewButtonWithIcon("", theme.ViewRefreshIcon(), v.reload)
+	header := container.NewBorder(nil, nil,
+		container.NewHBox(projectsBtn, i18n.BindLabel("roadmap.zoom"), v.zoomSelect),
+		refreshBtn, v.status)
+	v.object = container.NewBorder(header, nil, nil, nil, v.scroll)
+	return v
+}
+
+func roadmapZoomOptions() []string {
+	return []string{i18n.T("roadmap.zoom_quarters"), i18n.T("roadmap.zoom_months"), i18n.T("roadmap.zoom_weeks")}
+}
+
+// Load fetches the favourite projects on first use and shows the roadmap of the
+// projects selected last time, or of all favourites.
+func (v *roadmapView) Load() {
+	if v.loaded {
+		return
+	}
+	v.loaded = true
+	v.status.SetText(i18n.T("board.loading"))
+	go func() {
+		projects, err := models.FetchFavouriteProjects(v.domain, v.user, v.token)
+		fyne.Do(func() {
+			if err != nil {
+				v.loaded = false
+				v.status.SetText(fmt.Sprintf(i18n.T("roadmap.error_load"), err))
+				return
+			}
+			v.projects = projects
+			v.selected = nil
+			if saved := v.prefs.String("roadmap_projects"); saved != "" {
+				v.selected = strings.Split(saved, ",")
+			} else {
+				for _, p := range projects {
+					v.selected = append(v.selected, p.Key)
+				}
+			}
+			v.reload()
+		})
+	}()
+}
+
+// showProjects lets the user choose the projects shown on the roadmap.
+func (v *roadmapView) showProjects() {
+	var options []string
+	keys := map[string]string{}
+	var checked []string
+	for _, p := range v.projects {
+		name := fmt.Sprintf("%s – %s", p.Key, p.Name)
+		options = append(options, name)
+		keys[name] = p.Key
+		for _, s := range v.selected {
+			if s == p.Key {
+				checked = append(checked, name)
+			}
+		}
+	}
+	group := widget.NewCheckGroup(options, nil)
+	group.SetSelected(checked)
+	scroll := container.NewVScroll(group)
+	scroll.SetMinSize(fyne.NewSize(350, 300))
+	dialog.ShowCustomConfirm(i18n.T("roadmap.projects"), i18n.T("bulk.apply"), i18n.T("bulk.cancel"), scroll, func(ok bool) {
+		if !ok {
+			return
+		}
+		v.selected = nil
+		for _, name := range group.Selected {
+			v.selected = append(v.selected, keys[name])
+		}
+		v.prefs.SetString("roadmap_projects", strings.Join(v.selected, ","))
+		v.reload()
+	}, v.w)
+}
+
+func (v *roadmapView) reload() {
+	v.request++
+	request := v.request
+	projects := append([]string{}, v.selected...)
+	v.status.SetText(i18n.T("board.loading"))
+	go func() {
+		roadmap, err := models.FetchRoadmap(v.domain, v.user, v.token, projects)
+		fyne.Do(func() {
+			if request != v.request {
+				return
+			}
+			if err != nil {
+				v.status.SetText(fmt.Sprintf(i18n.T("roadmap.error_load"), err))
+				return
+			}
+			v.roadmap = roadmap
+			v.status.SetText(fmt.Sprintf(i18n.T("roadmap.epic_count"), len(roadmap.Epics)))
+			v.render()
+		})
+	}()
+}
+
+// barDates returns the dates a bar is drawn with; a missing start or due date is
+// assumed roadmapDefaultDays away from the other. ok is false for epics without dates.
+func barDates(epic models.RoadmapEpic) (start, due time.Time, estimated, ok bool) {
+	switch {
+	case epic.Start.IsZero() && epic.Due.IsZero():
+		return time.Time{}, time.Time{}, false, false
+	case epic.Start.IsZero():
+		return epic.Due.AddDate(0, 0, -roadmapDefaultDays), epic.Due, true, true
+	case epic.Due.IsZero():
+		return epic.Start, epic.Start.AddDate(0, 0, roadmapDefaultDays), true, true
+	}
+	return epic.Start, epic.Due, epic.FromSprints, true
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// x returns the horizontal position of the start of the day.
+func (v *roadmapView) x(t time.Time) float32 {
+	return roadmapLabelWidth + float32(startOfDay(t).Sub(v.origin).Hours()/24)*v.perDay
+}
+
+func (v *roadmapView) render() {
+	v.perDay = roadmapZooms[max(v.zoomSelect.SelectedIndex(), 0)]
+	today := startOfDay(time.Now())
+	from, to := today, today
+	for _, epic := range v.roadmap.Epics {
+		if start, due, _, ok := barDates(epic); ok {
+			from, to = minTime(from, start), maxTime(to, due)
+		}
+	}
+	// start at the first of the month, end two weeks after the last due date
+	v.origin = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.Local)
+	end := to.AddDate(0, 0, 14)
+
+	width := v.x(end) + 20
+	height := float32(roadmapHeader + len(v.roadmap.Epics)*roadmapRowHeight + 20)
+	size := canvas.NewRectangle(color.Transparent)
+	size.SetMinSize(fyne.NewSize(width, height))
+	size.Resize(fyne.NewSize(width, height))
+	objects := []fyne.CanvasObject{size}
+
+	// month grid with labels
+	grid := theme.Color(theme.ColorNameSeparator)
+	for m := v.origin; m.Before(end); m = m.AddDate(0, 1, 0) {
+		x := v.x(m)
+		objects = append(objects, roadmapLine(fyne.NewPos(x, roadmapHeader-8), fyne.NewPos(x, height), grid, 1))
+		label := canvas.NewText(m.Format("Jan 2006"), theme.Color(theme.ColorNamePlaceHolder))
+		label.TextSize = theme.TextSize() - 2
+		label.Move(fyne.NewPos(x+4, 8))
+		objects = append(objects, label)
+	}
+	todayX := v.x(today)
+	objects = append(objects, roadmapLine(fyne.NewPos(todayX, roadmapHeader-8), fyne.NewPos(todayX, height), theme.Color(theme.ColorNameError), 1))
+
+	bars := map[string]*roadmapBar{}
+	for i, epic := range v.roadmap.Epics {
+		y := float32(roadmapHeader + i*roadmapRowHeight)
+		label := canvas.NewText(truncateText(epic.Key+" "+epic.Summary, roadmapLabelWidth-16, theme.TextSize()), theme.Color(theme.ColorNameForeground))
+		label.Move(fyne.NewPos(8, y+(roadmapRowHeight-label.MinSize().Height)/2))
+		objects = append(objects, label)
+
+		start, due, estimated, ok := barDates(epic)
+		if !ok {
+			e := epic
+			schedule := widget.NewButtonWithIcon(i18n.T("roadmap.schedule"), theme.ContentAddIcon(), func() {
+				v.changeDates(e.Key, today, today.AddDate(0, 0, roadmapDefaultDays))
+			})
+			schedule.Importance = widget.LowImportance
+			schedule.Resize(schedule.MinSize())
+			schedule.Move(fyne.NewPos(todayX+4, y+(roadmapRowHeight-schedule.MinSize().Height)/2))
+			objects = append(objects, schedule)
+			continue
+		}
+		bar := newRoadmapBar(v, epic, estimated)
+		bar.Move(fyne.NewPos(v.x(start), y+4))
+		bar.Resize(fyne.NewSize(max(v.x(due.AddDate(0, 0, 1))-v.x(start), roadmapEdge*2), roadmapRowHeight-8))
+		bars[epic.Key] = bar
+		objects = append(objects, bar)
+	}
+
+	// dependencies are drawn from the end of one bar to the start of the other, red if
+	// the dependent epic starts before the other one ends
+	for _, dep := range v.roadmap.Dependencies {
+		from, to := bars[dep.From], bars[dep.To]
+		if from == nil || to == nil {
+			continue
+		}
+		c := theme.Color(theme.ColorNamePlaceHolder)
+		if to.Position().X < from.Position().X+from.Size().Width {
+			c = theme.Color(theme.ColorNameError)
+		}
+		objects = append(objects, roadmapArrow(
+			fyne.NewPos(from.Position().X+from.Size().Width, from.Position().Y+from.Size().Height/2),
+			fyne.NewPos(to.Position().X, to.Position().Y+to.Size().Height/2), c)...)
+	}
+
+	v.content.Objects = objects
+	v.content.Refresh()
+	v.scroll.Refresh()
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func truncateText(text string, width, size float32) string {
+	if fyne.MeasureText(text, size, fyne.TextStyle{}).Width <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 1 && fyne.MeasureText(string(runes)+"…", size, fyne.TextStyle{}).Width > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+func roadmapLine(from, to fyne.Position, c color.Color, width float32) *canvas.Line {
+	line := canvas.NewLine(c)
+	line.StrokeWidth = width
+	line.Position1, line.Position2 = from, to
+	return line
+}
+
+// roadmapArrow routes an arrow out of the right end of one bar into the left end of another.
+func roadmapArrow(from, to fyne.Position, c color.Color) []fyne.CanvasObject {
+	out := from.AddXY(roadmapEdge, 0)
+	in := to.SubtractXY(roadmapEdge, 0)
+	middle := float32(math.Round(float64(out.Y+in.Y) / 2))
+	return []fyne.CanvasObject{
+		roadmapLine(from, out, c, 1.5),
+		roadmapLine(out, fyne.NewPos(out.X, middle), c, 1.5),
+		roadmapLine(fyne.NewPos(out.X, middle), fyne.NewPos(in.X, middle), c, 1.5),
+		roadmapLine(fyne.NewPos(in.X, middle), in, c, 1.5),
+		roadmapLine(in, to, c, 1.5),
+		roadmapLine(to, to.AddXY(-5, -4), c, 1.5),
+		roadmapLine(to, to.AddXY(-5, 4), c, 1.5),
+	}
+}
+
+// confirmDates asks before dates that were estimated are saved as the epic's own dates.
+func (v *roadmapView) confirmDates(key string, start, due time.Time) {
+	message := fmt.Sprintf(i18n.T("roadmap.confirm_estimated"), key, start.Format(sprintDateFormat), due.Format(sprintDateFormat))
+	dialog.ShowConfirm(i18n.T("roadmap.confirm_estimated_title"), message, func(ok bool) {
+		if !ok {
+			v.render()
+			return
+		}
+		v.changeDates(key, start, due)
+	}, v.w)
+}
+
+// changeDates shows the new dates at once and saves them to the epic.
+func (v *roadmapView) changeDates(key string, start, due time.Time) {
+	if due.Before(start) {
+		due = start
+	}
+	for i := range v.roadmap.Epics {
+		if v.roadmap.Epics[i].Key == key {
+			v.roadmap.Epics[i].Start, v.roadmap.Epics[i].Due, v.roadmap.Epics[i].FromSprints = start, due, false
+		}
+	}
+	v.render()
+
+	fields := v.roadmap.Fields
+	go func() {
+		err := models.UpdateEpicDates(v.domain, v.user, v.token, fields, key, start, due)
+		fyne.Do(func() {
+			if err != nil {
+				dialog.ShowError(err, v.w)
+				v.reload()
+				return
+			}
+			v.status.SetText(fmt.Sprintf(i18n.T("roadmap.saved"), key, start.Format(sprintDateFormat), due.Format(sprintDateFormat)))
+		})
+	}()
+}
+
+// Drag modes of a roadmap bar.
+const (
+	roadmapDragMove = iota
+	roadmapDragStart
+	roadmapDragEnd
+)
+
+// roadmapBar is an epic on the timeline, filled with the share of done and in progress
+// children. Dragging moves it, dragging an edge changes the start or due date.
+type roadmapBar struct {
+	widget.BaseWidget
+
+	view      *roadmapView
+	epic      models.RoadmapEpic
+	estimated bool
+
+	dragging bool
+	mode     int
+	dx       float32
+	origPos  fyne.Position
+	origSize fyne.Size
+	hoverX   float32
+}
+
+func newRoadmapBar(view *roadmapView, epic models.RoadmapEpic, estimated bool) *roadmapBar {
+	b := &roadmapBar{view: view, epic: epic, estimated: estimated}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+// CreateRenderer implements fyne.Widget.
+func (b *roadmapBar) CreateRenderer() fyne.WidgetRenderer {
+	base := withAlpha(theme.Color(theme.ColorNamePrimary), 0x40)
+	bg := canvas.NewRectangle(base)
+	bg.CornerRadius = 4
+	if b.estimated {
+		// dates that are assumed or taken from sprints are shown outlined
+		bg.FillColor = withAlpha(theme.Color(theme.ColorNamePrimary), 0x18)
+		bg.StrokeColor = theme.Color(theme.ColorNamePrimary)
+		bg.StrokeWidth = 1
+	}
+	done := canvas.NewRectangle(withAlpha(mustHexColor("#36b37e"), 0xc0))
+	progress := canvas.NewRectangle(withAlpha(mustHexColor("#0052cc"), 0x80))
+
+	doneCount, total := b.epic.Progress()
+	text := b.epic.Key
+	if total > 0 {
+		text = fmt.Sprintf("%s  %d/%d", b.epic.Key, doneCount, total)
+	}
+	label := canvas.NewText(text, theme.Color(theme.ColorNameForeground))
+	label.TextSize = theme.TextSize() - 2
+	return &roadmapBarRenderer{bar: b, bg: bg, done: done, progress: progress, label: label, text: text}
+}
+
+// Tapped implements fyne.Tappable.
+func (b *roadmapBar) Tapped(*fyne.PointEvent) {
+	b.view.openIssue(b.epic.Key)
+}
+
+// Dragged implements fyne.Draggable.
+func (b *roadmapBar) Dragged(e *fyne.DragEvent) {
+	if !b.dragging {
+		b.dragging, b.dx = true, 0
+		b.origPos, b.origSize = b.Position(), b.Size()
+		b.mode = b.modeAt(e.Position.X - e.Dragged.DX)
+	}
+	b.dx += e.Dragged.DX
+	switch b.mode {
+	case roadmapDragMove:
+		b.Move(b.origPos.AddXY(b.dx, 0))
+	case roadmapDragStart:
+		dx := min(b.dx, b.origSize.Width-roadmapEdge*2)
+		b.Move(b.origPos.AddXY(dx, 0))
+		b.Resize(fyne.NewSize(b.origSize.Width-dx, b.origSize.Height))
+	case roadmapDragEnd:
+		b.Resize(fyne.NewSize(max(b.origSize.Width+b.dx, roadmapEdge*2), b.origSize.Height))
+	}
+}
+
+// DragEnd implements fyne.Draggable.
+func (b *roadmapBar) DragEnd() {
+	b.dragging = false
+	days := int(math.Round(float64(b.dx / b.view.perDay)))
+	if days == 0 {
+		b.Move(b.origPos)
+		b.Resize(b.origSize)
+		return
+	}
+	start, due, _, _ := barDates(b.epic)
+	switch b.mode {
+	case roadmapDragMove:
+		start, due = start.AddDate(0, 0, days), due.AddDate(0, 0, days)
+	case roadmapDragStart:
+		start = start.AddDate(0, 0, days)
+	case roadmapDragEnd:
+		due = due.AddDate(0, 0, days)
+	}
+	if b.estimated {
+		b.view.confirmDates(b.epic.Key, start, due)
+		return
+	}
+	b.view.changeDates(b.epic.Key, start, due)
+}
+
+// modeAt returns the drag mode at x; the start edge only resizes if the site has a
+// start date field to save it to.
+func (b *roadmapBar) modeAt(x float32) int {
+	switch {
+	case x < roadmapEdge && b.view.roadmap.Fields.StartDate != "":
+		return roadmapDragStart
+	case x > b.Size().Width-roadmapEdge:
+		return roadmapDragEnd
+	}
+	return roadmapDragMove
+}
+
+// Cursor implements desktop.Cursorable, the edges show a resize cursor.
+func (b *roadmapBar) Cursor() desktop.Cursor {
+	if b.modeAt(b.hoverX) != roadmapDragMove {
+		return desktop.HResizeCursor
+	}
+	return desktop.PointerCursor
+}
+
+// MouseIn implements desktop.Hoverable.
+func (b *roadmapBar) MouseIn(e *desktop.MouseEvent) { b.hoverX = e.Position.X }
+
+// MouseMoved implements desktop.Hoverable.
+func (b *roadmapBar) MouseMoved(e *desktop.MouseEvent) { b.hoverX = e.Position.X }
+
+// MouseOut implements desktop.Hoverable.
+func (b *roadmapBar) MouseOut() {}
+
+type roadmapBarRenderer struct {
+	bar                *roadmapBar
+	bg, done, progress *canvas.Rectangle
+	label              *canvas.Text
+	// text is the untruncated label
+	text string
+}
+
+func (r *roadmapBarRenderer) Layout(size fyne.Size) {
+	r.bg.Resize(size)
+	doneCount, total := r.bar.epic.Progress()
+	if total == 0 {
+		r.done.Resize(fyne.NewSize(0, 0))
+		r.progress.Resize(fyne.NewSize(0, 0))
+	} else {
+		doneWidth := size.Width * float32(doneCount) / float32(total)
+		progressWidth := size.Width * float32(r.bar.epic.Children["indeterminate"]) / float32(total)
+		r.done.Resize(fyne.NewSize(doneWidth, 4))
+		r.done.Move(fyne.NewPos(0, size.Height-4))
+		r.progress.Resize(fyne.NewSize(progressWidth, 4))
+		r.progress.Move(fyne.NewPos(doneWidth, size.Height-4))
+	}
+	r.label.Text = truncateText(r.text, size.Width-8, r.label.TextSize)
+	r.label.Move(fyne.NewPos(4, (size.Height-4-r.label.MinSize().Height)/2))
+	r.label.Resize(r.label.MinSize())
+}
+
+func (r *roadmapBarRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(roadmapEdge*2, roadmapRowHeight-8)
+}
+
+func (r *roadmapBarRenderer) Refresh() {
+	r.Layout(r.bar.Size())
+	canvas.Refresh(r.bar)
+}
+
+func (r *roadmapBarRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.bg, r.done, r.progress, r.label}
+}
+
+func (r *roadmapBarRenderer) Destroy() {}
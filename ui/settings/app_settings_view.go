@@ -6,6 +6,7 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
@@ -23,6 +24,22 @@ func BuildAppSettings(app fyne.App, w fyne.Window) fyne.CanvasObject {
 		prefs.SetBool("experimental_enabled", checked)
 	}
 
+	clipboardDetection := i18n.BindCheckbox("settings.clipboard_detection")
+	clipboardDetection.SetChecked(prefs.BoolWithFallback("clipboard_detection", true))
+	clipboardDetection.OnChanged = func(checked bool) {
+		prefs.SetBool("clipboard_detection", checked)
+	}
+
+	hideOnClose := i18n.BindCheckbox("settings.tray_hide_on_close")
+	hideOnClose.SetChecked(prefs.Bool("tray_hide_on_close"))
+	hideOnClose.OnChanged = func(checked bool) {
+		prefs.SetBool("tray_hide_on_close", checked)
+	}
+	// there is no tray to bring the window back without a desktop driver
+	if _, ok := app.(desktop.App); !ok {
+		hideOnClose.Hide()
+	}
+
 	languages := map[string]string{
 		"English": "en",
 		"Deutsch": "de",
@@ -54,6 +71,8 @@ func BuildAppSettings(app fyne.App, w fyne.Window) fyne.CanvasObject {
 
 	formContent := container.NewVBox(
 		enableExperimental,
+		clipboardDetection,
+		hideOnClose,
 		widget.NewLabelWithStyle(i18n.T("settings.app_config"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		i18n.BindLabel("settings.language"),
 		langSelect,
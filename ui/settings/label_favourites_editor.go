@@ -0,0 +1,194 @@
+package settings
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+	"github.com/scramb/backlog-manager/ui/components"
+)
+
+// favouritesEditor edits the groups of favourite labels: name, colour and order of the
+// groups and order and group of their labels.
+type favouritesEditor struct {
+	object fyne.CanvasObject
+	box    *fyne.Container
+	favs   models.LabelFavourites
+
+	// OnChanged is called when groups or labels are added, removed or moved.
+	OnChanged func()
+}
+
+func newFavouritesEditor() *favouritesEditor {
+	e := &favouritesEditor{box: container.NewVBox()}
+	addGroupBtn := i18n.BindButton("labels.group_add", theme.ContentAddIcon(), func() {
+		e.favs.Groups = append(e.favs.Groups, models.LabelGroup{Name: i18n.T("labels.group_new")})
+		e.changed()
+	})
+	e.object = container.NewVBox(e.box, container.NewHBox(addGroupBtn))
+	return e
+}
+
+// Set shows the given favourites.
+func (e *favouritesEditor) Set(favs models.LabelFavourites) {
+	e.favs = favs
+	e.refresh()
+}
+
+// Favourites returns the edited favourites.
+func (e *favouritesEditor) Favourites() models.LabelFavourites {
+	return e.favs
+}
+
+// AddLabel adds the label to the group, creating a first group if there is none.
+func (e *favouritesEditor) AddLabel(group int, label string) {
+	if len(e.favs.Groups) == 0 {
+		e.favs.Groups = []models.LabelGroup{{Name: i18n.T("labels.group_default")}}
+	}
+	if group < 0 || group >= len(e.favs.Groups) {
+		group = 0
+	}
+	e.favs.Add(group, label)
+	e.refresh()
+}
+
+// RemoveLabel drops the label from the favourites.
+func (e *favouritesEditor) RemoveLabel(label string) {
+	e.favs.Remove(label)
+	e.refresh()
+}
+
+// GroupNames returns the names of the groups in order.
+func (e *favouritesEditor) GroupNames() []string {
+	names := make([]string, len(e.favs.Groups))
+	for i, g := range e.favs.Groups {
+		names[i] = g.Name
+	}
+	return names
+}
+
+func (e *favouritesEditor) changed() {
+	e.refresh()
+	if e.OnChanged != nil {
+		e.OnChanged()
+	}
+}
+
+func colorNames() []string {
+	names := []string{i18n.T("labels.color_none")}
+	for _, c := range models.LabelGroupColors {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func (e *favouritesEditor) refresh() {
+	e.box.Objects = nil
+	if len(e.favs.Groups) == 0 {
+		e.box.Add(widget.NewLabel(i18n.T("labels.no_favourites")))
+	}
+
+	for gi := range e.favs.Groups {
+		i := gi
+		group := &e.favs.Groups[i]
+
+		name := widget.NewEntry()
+		name.SetText(group.Name)
+		name.OnChanged = func(text string) { e.favs.Groups[i].Name = text }
+		name.OnSubmitted = func(string) { e.changed() }
+
+		colorSelect := widget.NewSelect(colorNames(), func(selected string) {
+			e.favs.Groups[i].Color = ""
+			for _, c := range models.LabelGroupColors {
+				if c.Name == selected {
+					e.favs.Groups[i].Color = c.Hex
+				}
+			}
+			e.changed()
+		})
+		colorSelect.Selected = i18n.T("labels.color_none")
+		for _, c := range models.LabelGroupColors {
+			if c.Hex == group.Color {
+				colorSelect.Selected = c.Name
+			}
+		}
+
+		swatch := canvas.NewRectangle(theme.Color(theme.ColorNameButton))
+		if c, ok := components.HexColor(group.Color); ok {
+			swatch.FillColor = c
+		}
+		swatch.CornerRadius = 4
+		swatch.SetMinSize(fyne.NewSize(16, 16))
+
+		upBtn := widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() {
+			e.favs.Groups[i-1], e.favs.Groups[i] = e.favs.Groups[i], e.favs.Groups[i-1]
+			e.changed()
+		})
+		if i == 0 {
+			upBtn.Disable()
+		}
+		downBtn := widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() {
+			e.favs.Groups[i+1], e.favs.Groups[i] = e.favs.Groups[i], e.favs.Groups[i+1]
+			e.changed()
+		})
+		if i == len(e.favs.Groups)-1 {
+			downBtn.Disable()
+		}
+		deleteBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+			e.favs.Groups = append(e.favs.Groups[:i], e.favs.Groups[i+1:]...)
+			e.changed()
+		})
+
+		header := container.NewBorder(nil, nil, container.NewCenter(swatch),
+			container.NewHBox(colorSelect, upBtn, downBtn, deleteBtn), name)
+		e.box.Add(header)
+
+		labels := container.NewVBox()
+		for li := range group.Labels {
+			j := li
+			label := group.Labels[j]
+
+			moveSelect := widget.NewSelect(e.GroupNames(), nil)
+			moveSelect.Selected = group.Name
+			moveSelect.OnChanged = func(string) {
+				if target := moveSelect.SelectedIndex(); target >= 0 && target != i {
+					e.favs.Add(target, label)
+					e.changed()
+				}
+			}
+			labelUp := widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() {
+				l := e.favs.Groups[i].Labels
+				l[j-1], l[j] = l[j], l[j-1]
+				e.changed()
+			})
+			if j == 0 {
+				labelUp.Disable()
+			}
+			labelDown := widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() {
+				l := e.favs.Groups[i].Labels
+				l[j+1], l[j] = l[j], l[j+1]
+				e.changed()
+			})
+			if j == len(group.Labels)-1 {
+				labelDown.Disable()
+			}
+			removeBtn := widget.NewButtonWithIcon("", theme.ContentRemoveIcon(), func() {
+				e.favs.Remove(label)
+				e.changed()
+			})
+			for _, b := range []*widget.Button{labelUp, labelDown, removeBtn} {
+				b.Importance = widget.LowImportance
+			}
+
+			labels.Add(container.NewBorder(nil, nil, nil,
+				container.NewHBox(moveSelect, labelUp, labelDown, removeBtn),
+				widget.NewLabel(label)))
+		}
+		e.box.Add(container.NewPadded(labels))
+		e.box.Add(widget.NewSeparator())
+	}
+	e.box.Refresh()
+}
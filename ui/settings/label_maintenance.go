@@ -0,0 +1,252 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// maxPreviewEntries limits the issues listed in the dry run.
+const maxPreviewEntries = 100
+
+// showLabelMaintenance opens a window to merge near-duplicate labels of the project or
+// rename a label. Every run is logged, interrupted runs can be resumed and runs undone.
+// onChanged is called after labels were changed.
+func showLabelMaintenance(app fyne.App, domain, user, token, project string, counts map[string]int, onChanged func()) {
+	prefs := app.Preferences()
+	win := app.NewWindow(fmt.Sprintf(i18n.T("labels.maintenance_title"), project))
+
+	groupsBox := container.NewVBox()
+	groups := models.SimilarLabels(counts)
+	if len(groups) == 0 {
+		groupsBox.Add(i18n.BindLabel("labels.no_similar"))
+	}
+
+	logBox := container.NewVBox()
+	var refreshLog func()
+
+	// execute applies or undoes a run and shows the progress
+	execute := func(run models.LabelMergeRun, undo bool) {
+		progress := widget.NewProgressBar()
+		status := widget.NewLabel("")
+		d := dialog.NewCustomWithoutButtons(i18n.T("labels.running"), container.NewVBox(progress, status), win)
+		d.Resize(fyne.NewSize(400, 120))
+		d.Show()
+
+		go func() {
+			update := func(done, total int) {
+				fyne.Do(func() {
+					progress.SetValue(float64(done) / float64(total))
+					status.SetText(fmt.Sprintf(i18n.T("labels.progress"), done, total))
+				})
+			}
+			var results []models.BulkResult
+			if undo {
+				results = models.UndoLabelMerge(domain, user, token, prefs, &run, update)
+			} else {
+				results = models.ApplyLabelMerge(domain, user, token, prefs, &run, update)
+			}
+			failed := len(models.FailedIssues(results))
+			fyne.Do(func() {
+				d.Hide()
+				models.ClearLabelCache(prefs, project)
+				refreshLog()
+				if onChanged != nil {
+					onChanged()
+				}
+				message := fmt.Sprintf(i18n.T("bulk.summary"), len(results)-failed, failed)
+				if failed > 0 {
+					message += "\n" + i18n.T("labels.resume_hint")
+				}
+				dialog.ShowInformation(i18n.T("labels.finished"), message, win)
+			})
+		}()
+	}
+
+	// preview looks up the affected issues and asks before anything is changed
+	preview := func(sources []string, target string) {
+		loading := dialog.NewCustomWithoutButtons(i18n.T("labels.preview"), widget.NewProgressBarInfinite(), win)
+		loading.Show()
+		go func() {
+			issues, err := models.FetchLabelIssues(domain, user, token, project, sources)
+			fyne.Do(func() {
+				loading.Hide()
+				if err != nil {
+					dialog.ShowError(err, win)
+					return
+				}
+				run := models.PlanLabelMerge(project, sources, target, issues)
+				if len(run.Entries) == 0 {
+					dialog.ShowInformation(i18n.T("labels.preview"), i18n.T("labels.nothing_to_do"), win)
+					return
+				}
+
+				lines := []string{}
+				for i, e := range run.Entries {
+					if i == maxPreviewEntries {
+						lines = append(lines, fmt.Sprintf(i18n.T("labels.preview_more"), len(run.Entries)-i))
+						break
+					}
+					change := "-" + strings.Join(e.Removed, " -")
+					if e.Added {
+						change += " +" + target
+					}
+					lines = append(lines, fmt.Sprintf("%s  %s", e.Key, change))
+				}
+				list := widget.NewLabel(strings.Join(lines, "\n"))
+				scroll := container.NewVScroll(list)
+				scroll.SetMinSize(fyne.NewSize(420, 240))
+				content := container.NewBorder(
+					widget.NewLabel(fmt.Sprintf(i18n.T("labels.preview_count"), len(run.Entries), strings.Join(sources, ", "), target)),
+					nil, nil, nil, scroll)
+
+				dialog.ShowCustomConfirm(i18n.T("labels.preview"), i18n.T("labels.apply"), i18n.T("bulk.cancel"), content, func(ok bool) {
+					if ok {
+						execute(run, false)
+					}
+				}, win)
+			})
+		}()
+	}
+
+	validateTarget := func(target string) error {
+		if target == "" {
+			return errors.New(i18n.T("labels.target_missing"))
+		}
+		return ValidateLabel(target)
+	}
+
+	showMergeDialog := func(group []string) {
+		checks := container.NewVBox()
+		sourceChecks := map[string]*widget.Check{}
+		for _, l := range group {
+			c := widget.NewCheck(fmt.Sprintf(i18n.T("settings.label_count"), l, counts[l]), nil)
+			// only labels that differ in case or separators are merged by default, typos are opt-in
+			c.SetChecked(models.LabelsEquivalent(l, group[0]))
+			sourceChecks[l] = c
+			checks.Add(c)
+		}
+		target := widget.NewSelectEntry(group)
+		target.SetText(group[0])
+
+		content := container.NewVBox(i18n.BindLabel("labels.sources"), checks, i18n.BindLabel("labels.target"), target)
+		dialog.ShowCustomConfirm(i18n.T("labels.merge"), i18n.T("labels.preview"), i18n.T("bulk.cancel"), content, func(ok bool) {
+			if !ok {
+				return
+			}
+			name := strings.TrimSpace(target.Text)
+			if err := validateTarget(name); err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			var sources []string
+			for _, l := range group {
+				if sourceChecks[l].Checked && l != name {
+					sources = append(sources, l)
+				}
+			}
+			if len(sources) == 0 {
+				dialog.ShowInformation(i18n.T("labels.merge"), i18n.T("labels.nothing_to_do"), win)
+				return
+			}
+			preview(sources, name)
+		}, win)
+	}
+
+	for _, g := range groups {
+		group := g
+		parts := make([]string, len(group))
+		for i, l := range group {
+			parts[i] = fmt.Sprintf(i18n.T("settings.label_count"), l, counts[l])
+		}
+		text := widget.NewLabel(strings.Join(parts, " · "))
+		text.Wrapping = fyne.TextWrapWord
+		mergeBtn := i18n.BindButton("labels.merge", theme.ContentRedoIcon(), func() { showMergeDialog(group) })
+		groupsBox.Add(container.NewBorder(nil, nil, nil, mergeBtn, text))
+	}
+
+	renameBtn := i18n.BindButton("labels.rename", theme.DocumentCreateIcon(), func() {
+		labelSelect := widget.NewSelect(models.SortedLabels(counts), nil)
+		newName := widget.NewEntry()
+		dialog.ShowForm(i18n.T("labels.rename"), i18n.T("labels.preview"), i18n.T("bulk.cancel"),
+			[]*widget.FormItem{
+				widget.NewFormItem(i18n.T("labels.rename_from"), labelSelect),
+				widget.NewFormItem(i18n.T("labels.target"), newName),
+			},
+			func(ok bool) {
+				if !ok || labelSelect.Selected == "" {
+					return
+				}
+				name := strings.TrimSpace(newName.Text)
+				if err := validateTarget(name); err != nil {
+					dialog.ShowError(err, win)
+					return
+				}
+				if name == labelSelect.Selected {
+					return
+				}
+				preview([]string{labelSelect.Selected}, name)
+			}, win)
+	})
+
+	refreshLog = func() {
+		logBox.Objects = nil
+		runs := []models.LabelMergeRun{}
+		for _, r := range models.LoadLabelMergeRuns(prefs) {
+			if r.Project == project {
+				runs = append(runs, r)
+			}
+		}
+		if len(runs) == 0 {
+			logBox.Add(i18n.BindLabel("labels.log_empty"))
+		}
+		for _, r := range runs {
+			run := r
+			done, undone := run.Progress()
+			pending := len(run.Entries) - done - undone
+			text := widget.NewLabel(fmt.Sprintf(i18n.T("labels.log_entry"),
+				run.Started.Format("2006-01-02 15:04"), strings.Join(run.Sources, ", "), run.Target, done, len(run.Entries), undone))
+			text.Wrapping = fyne.TextWrapWord
+
+			resumeBtn := i18n.BindButton("labels.resume", theme.MediaPlayIcon(), func() { execute(run, false) })
+			if pending == 0 || undone > 0 {
+				resumeBtn.Disable()
+			}
+			undoBtn := i18n.BindButton("labels.undo", theme.ContentUndoIcon(), func() {
+				dialog.ShowConfirm(i18n.T("labels.undo"), fmt.Sprintf(i18n.T("labels.undo_confirm"), done), func(ok bool) {
+					if ok {
+						execute(run, true)
+					}
+				}, win)
+			})
+			if done == 0 {
+				undoBtn.Disable()
+			}
+			logBox.Add(container.NewBorder(nil, nil, nil, container.NewHBox(resumeBtn, undoBtn), text))
+		}
+		logBox.Refresh()
+	}
+	refreshLog()
+
+	content := container.NewVBox(
+		widget.NewLabelWithStyle(i18n.T("labels.similar"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		groupsBox,
+		container.NewHBox(layout.NewSpacer(), renameBtn),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle(i18n.T("labels.log"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		logBox,
+	)
+	win.SetContent(container.NewVScroll(content))
+	win.Resize(fyne.NewSize(640, 560))
+	win.Show()
+}
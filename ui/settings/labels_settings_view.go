@@ -1,8 +1,10 @@
 package settings
 
 import (
+	"errors"
 	"fmt"
-	"strings"
+	"sort"
+	"sync/atomic"
 
 	"github.com/scramb/backlog-manager/internal/i18n"
 	"github.com/scramb/backlog-manager/internal/models"
@@ -10,11 +12,26 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
+// ValidateLabel checks a new label like models.ValidateLabel, with a translated error.
+func ValidateLabel(label string) error {
+	err := models.ValidateLabel(label)
+	switch {
+	case errors.Is(err, models.ErrLabelWhitespace):
+		return errors.New(i18n.T("labels.error_whitespace"))
+	case errors.Is(err, models.ErrLabelTooLong):
+		return fmt.Errorf(i18n.T("labels.error_too_long"), models.MaxLabelLength)
+	}
+	return err
+}
+
 // BuildLabelSettings builds the label configuration settings tab.
+// The labels of a project come from a full scan of its issues, which runs in the background
+// and is cached per project; the labels of the whole site can be shown in addition.
 func BuildLabelSettings(app fyne.App, w fyne.Window) fyne.CanvasObject {
 	prefs := app.Preferences()
 
@@ -22,6 +39,10 @@ func BuildLabelSettings(app fyne.App, w fyne.Window) fyne.CanvasObject {
 	projectSelect.PlaceHolder = i18n.T("settings.label_project")
 
 	labelContainer := container.NewVBox()
+	labelChecks := map[string]*widget.Check{}
+	favourites := newFavouritesEditor()
+	// checked labels are added to this group
+	groupSelect := widget.NewSelect([]string{}, nil)
 
 	domain := prefs.String("jira_domain")
 	user := prefs.String("jira_user")
@@ -38,70 +59,285 @@ func BuildLabelSettings(app fyne.App, w fyne.Window) fyne.CanvasObject {
 		}
 	}
 
+	statusLabel := widget.NewLabel("")
+	progress := widget.NewProgressBar()
+	progress.Hide()
+	var siteLabels []string
+	var counts map[string]int
+	// scan increases with every started scan, a running scan stops when it is outdated;
+	// the scan goroutine reads it, so it is atomic
+	var scan atomic.Int64
+
+	updateGroupSelect := func() {
+		names := favourites.GroupNames()
+		index := groupSelect.SelectedIndex()
+		groupSelect.Options = names
+		switch {
+		case len(names) == 0:
+			groupSelect.ClearSelected()
+		case index < 0 || index >= len(names):
+			groupSelect.SetSelectedIndex(0)
+		default:
+			groupSelect.SetSelectedIndex(index)
+		}
+		groupSelect.Refresh()
+	}
+
+	showSiteLabels := i18n.BindCheckbox("settings.labels_site")
+
+	// showLabels lists the project labels by usage, then the other site labels.
+	// Favourites that were not found are listed as well. Checking a label makes it a favourite.
+	showLabels := func() {
+		checked := favourites.Favourites().All()
+		labels := models.SortedLabels(counts)
+		sort.SliceStable(labels, func(i, j int) bool { return counts[labels[i]] > counts[labels[j]] })
+		known := map[string]bool{}
+		for _, l := range labels {
+			known[l] = true
+		}
+		if showSiteLabels.Checked {
+			for _, l := range siteLabels {
+				if !known[l] {
+					known[l] = true
+					labels = append(labels, l)
+				}
+			}
+		}
+		for _, l := range checked {
+			if !known[l] {
+				known[l] = true
+				labels = append(labels, l)
+			}
+		}
+
+		labelChecks = map[string]*widget.Check{}
+		grid := container.NewGridWithColumns(4)
+		for _, label := range labels {
+			cb := widget.NewCheck(fmt.Sprintf(i18n.T("settings.label_count"), label, counts[label]), nil)
+			labelChecks[label] = cb
+			grid.Add(cb)
+		}
+		for _, s := range checked {
+			if cb, ok := labelChecks[s]; ok {
+				cb.SetChecked(true)
+			}
+		}
+		for l, c := range labelChecks {
+			label := l
+			c.OnChanged = func(on bool) {
+				if on {
+					favourites.AddLabel(groupSelect.SelectedIndex(), label)
+				} else {
+					favourites.RemoveLabel(label)
+				}
+				updateGroupSelect()
+			}
+		}
+		labelContainer.Objects = []fyne.CanvasObject{grid}
+		labelContainer.Refresh()
+	}
+
+	showCacheStatus := func(cache models.LabelCache) {
+		statusLabel.SetText(fmt.Sprintf(i18n.T("settings.labels_scanned"), len(cache.Counts), cache.Scanned.Format("2006-01-02 15:04")))
+	}
+
+	var refreshBtn *widget.Button
+	startScan := func(project string) {
+		current := scan.Add(1)
+		refreshBtn.Disable()
+		progress.SetValue(0)
+		progress.Show()
+		statusLabel.SetText(i18n.T("settings.labels_scanning"))
+
+		go func() {
+			result, err := models.ScanProjectLabels(domain, user, token, project, func(scanned, total int) bool {
+				fyne.Do(func() {
+					if current != scan.Load() {
+						return
+					}
+					if total == 0 {
+						progress.Hide()
+						statusLabel.SetText(fmt.Sprintf(i18n.T("settings.labels_scan_count"), scanned))
+						return
+					}
+					progress.SetValue(float64(scanned) / float64(total))
+					statusLabel.SetText(fmt.Sprintf(i18n.T("settings.labels_scan_progress"), scanned, total))
+				})
+				return current == scan.Load()
+			})
+			fyne.Do(func() {
+				if current != scan.Load() {
+					return
+				}
+				progress.Hide()
+				refreshBtn.Enable()
+				if err != nil {
+					statusLabel.SetText("")
+					dialog.ShowError(fmt.Errorf(i18n.T("settings.error_load_labels")+": %w", err), w)
+					return
+				}
+				models.SaveLabelCache(prefs, project, result)
+				cache, _ := models.LoadLabelCache(prefs, project)
+				showCacheStatus(cache)
+				counts = result
+				showLabels()
+			})
+		}()
+	}
+
+	refreshBtn = i18n.BindButton("settings.labels_refresh", theme.ViewRefreshIcon(), func() {
+		if projectSelect.Selected == "" {
+			dialog.ShowError(errors.New(i18n.T("settings.no_project_selected")), w)
+			return
+		}
+		startScan(projectSelect.Selected)
+	})
+
+	maintenanceBtn := i18n.BindButton("labels.maintenance", theme.SettingsIcon(), func() {
+		project := projectSelect.Selected
+		if project == "" {
+			dialog.ShowError(errors.New(i18n.T("settings.no_project_selected")), w)
+			return
+		}
+		showLabelMaintenance(app, domain, user, token, project, counts, func() {
+			if projectSelect.Selected == project {
+				startScan(project)
+			}
+		})
+	})
+
+	showSiteLabels.OnChanged = func(on bool) {
+		if !on || siteLabels != nil {
+			showLabels()
+			return
+		}
+		showSiteLabels.Disable()
+		go func() {
+			labels, err := models.FetchAllLabels(domain, user, token)
+			fyne.Do(func() {
+				showSiteLabels.Enable()
+				if err != nil {
+					showSiteLabels.SetChecked(false)
+					dialog.ShowError(fmt.Errorf(i18n.T("settings.error_load_labels")+": %w", err), w)
+					return
+				}
+				siteLabels = labels
+				showLabels()
+			})
+		}()
+	}
+
 	saveBtn := i18n.BindButton("settings.save", theme.ConfirmIcon(), func() {
 		currentProject := projectSelect.Selected
 		if currentProject == "" {
-			dialog.ShowError(fmt.Errorf(i18n.T("settings.no_project_selected")), w)
+			dialog.ShowError(errors.New(i18n.T("settings.no_project_selected")), w)
 			return
 		}
-		var selected []string
-		if len(labelContainer.Objects) > 0 {
-			// labelContainer contains grid(s) of checkboxes
-			for _, obj := range labelContainer.Objects {
-				if grid, ok := obj.(*fyne.Container); ok {
-					for _, cbObj := range grid.Objects {
-						if cb, ok := cbObj.(*widget.Check); ok && cb.Checked {
-							selected = append(selected, cb.Text)
-						}
-					}
-				}
-			}
+		if err := models.SaveLabelFavourites(prefs, currentProject, favourites.Favourites()); err != nil {
+			dialog.ShowError(err, w)
+			return
 		}
-		prefs.SetString(fmt.Sprintf("labels_%s", currentProject), strings.Join(selected, ","))
 		dialog.ShowInformation(i18n.T("settings.saved_title"), i18n.T("settings.labels_saved"), w)
 	})
 
 	projectSelect.OnChanged = func(project string) {
-		labelContainer.Objects = nil
-		labelContainer.Refresh()
-
-		domain := prefs.String("jira_domain")
-		user := prefs.String("jira_user")
-		token := models.TryDecrypt(prefs.String("jira_token"))
+		scan.Add(1)
+		progress.Hide()
+		refreshBtn.Enable()
+		counts = map[string]int{}
+		favourites.Set(models.LoadLabelFavourites(prefs, project))
+		groupSelect.ClearSelected()
+		updateGroupSelect()
 
-		labels, err := models.FetchProjectLabels(domain, user, token, project)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf(i18n.T("settings.error_load_labels")+": %w", err), w)
+		if cache, ok := models.LoadLabelCache(prefs, project); ok {
+			counts = cache.Counts
+			showCacheStatus(cache)
+			showLabels()
 			return
 		}
+		showLabels()
+		startScan(project)
+	}
+	favourites.OnChanged = func() {
+		updateGroupSelect()
+		showLabels()
+	}
 
-		// Restore saved selections
-		saved := prefs.String(fmt.Sprintf("labels_%s", project))
-		var selected []string
-		if saved != "" {
-			selected = strings.Split(saved, ",")
+	importBtn := i18n.BindButton("labels.import", theme.FolderOpenIcon(), func() {
+		project := projectSelect.Selected
+		if project == "" {
+			dialog.ShowError(errors.New(i18n.T("settings.no_project_selected")), w)
+			return
 		}
+		d := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if reader == nil {
+				return
+			}
+			defer reader.Close()
 
-		grid := container.NewGridWithColumns(5)
-		for _, label := range labels {
-			cb := widget.NewCheck(label, nil)
-			for _, s := range selected {
-				if s == label {
-					cb.SetChecked(true)
-					break
-				}
+			imported, err := models.ImportLabelFavourites(reader.URI().Name(), reader)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
 			}
-			grid.Add(cb)
+			dialog.ShowConfirm(i18n.T("labels.import"), fmt.Sprintf(i18n.T("labels.import_confirm"), project), func(ok bool) {
+				if !ok {
+					return
+				}
+				if err := models.SaveLabelFavourites(prefs, project, imported); err != nil {
+					dialog.ShowError(err, w)
+					return
+				}
+				favourites.Set(models.LoadLabelFavourites(prefs, project))
+				updateGroupSelect()
+				showLabels()
+			}, w)
+		}, w)
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".json", ".yaml", ".yml"}))
+		d.Show()
+	})
+
+	exportBtn := i18n.BindButton("labels.export", theme.DocumentSaveIcon(), func() {
+		project := projectSelect.Selected
+		if project == "" {
+			dialog.ShowError(errors.New(i18n.T("settings.no_project_selected")), w)
+			return
 		}
-		labelContainer.Add(grid)
-		labelContainer.Refresh()
-	}
+		d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if writer == nil {
+				return
+			}
+			defer writer.Close()
+
+			favs := favourites.Favourites()
+			favs.Project = project
+			if err := models.ExportLabelFavourites(writer, writer.URI().Name(), favs); err != nil {
+				dialog.ShowError(err, w)
+			}
+		}, w)
+		d.SetFileName(fmt.Sprintf("labels-%s.yaml", project))
+		d.Show()
+	})
 
 	formContent := container.NewVBox(
 		widget.NewLabelWithStyle(i18n.T("settings.label_config"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		i18n.BindLabel("settings.label_project"),
 		projectSelect,
+		container.NewBorder(nil, nil, nil, container.NewHBox(showSiteLabels, refreshBtn, maintenanceBtn), statusLabel),
+		progress,
+		container.NewBorder(nil, nil, i18n.BindLabel("labels.add_to_group"), nil, groupSelect),
 		labelContainer,
+		widget.NewSeparator(),
+		container.NewBorder(nil, nil, widget.NewLabelWithStyle(i18n.T("labels.favourites"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}), container.NewHBox(importBtn, exportBtn)),
+		favourites.object,
 	)
 
 	scroll := container.NewVScroll(formContent)
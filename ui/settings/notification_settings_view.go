@@ -0,0 +1,89 @@
+package settings
+
+import (
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// BuildNotificationSettings builds the background polling and notification settings tab.
+func BuildNotificationSettings(app fyne.App, w fyne.Window) fyne.CanvasObject {
+	prefs := app.Preferences()
+
+	enablePolling := i18n.BindCheckbox("settings.poll_enabled")
+	enablePolling.SetChecked(prefs.BoolWithFallback("poll_enabled", true))
+
+	intervalEntry := widget.NewEntry()
+	intervalEntry.SetText(strconv.Itoa(prefs.IntWithFallback("poll_interval_minutes", 5)))
+
+	notifyAssigned := i18n.BindCheckbox("settings.notify_assigned")
+	notifyAssigned.SetChecked(prefs.BoolWithFallback("notify_"+models.ChangeAssigned, true))
+	notifyComments := i18n.BindCheckbox("settings.notify_comments")
+	notifyComments.SetChecked(prefs.BoolWithFallback("notify_"+models.ChangeComment, true))
+	notifyStatus := i18n.BindCheckbox("settings.notify_status")
+	notifyStatus.SetChecked(prefs.BoolWithFallback("notify_"+models.ChangeStatus, true))
+
+	quietHours := i18n.BindCheckbox("settings.quiet_hours_enabled")
+	quietHours.SetChecked(prefs.Bool("quiet_hours_enabled"))
+	quietStart := widget.NewEntry()
+	quietStart.SetPlaceHolder("22:00")
+	quietStart.SetText(prefs.StringWithFallback("quiet_hours_start", "22:00"))
+	quietEnd := widget.NewEntry()
+	quietEnd.SetPlaceHolder("07:00")
+	quietEnd.SetText(prefs.StringWithFallback("quiet_hours_end", "07:00"))
+
+	saveBtn := i18n.BindButton("settings.save", theme.ConfirmIcon(), func() {
+		interval, err := strconv.Atoi(intervalEntry.Text)
+		if err != nil || interval < 1 {
+			dialog.ShowError(fmt.Errorf(i18n.T("settings.poll_interval_invalid"), intervalEntry.Text), w)
+			return
+		}
+		if quietHours.Checked && (!models.ValidClock(quietStart.Text) || !models.ValidClock(quietEnd.Text)) {
+			dialog.ShowError(fmt.Errorf(i18n.T("settings.quiet_hours_invalid"), quietStart.Text, quietEnd.Text), w)
+			return
+		}
+
+		prefs.SetBool("poll_enabled", enablePolling.Checked)
+		prefs.SetInt("poll_interval_minutes", interval)
+		prefs.SetBool("notify_"+models.ChangeAssigned, notifyAssigned.Checked)
+		prefs.SetBool("notify_"+models.ChangeComment, notifyComments.Checked)
+		prefs.SetBool("notify_"+models.ChangeStatus, notifyStatus.Checked)
+		prefs.SetBool("quiet_hours_enabled", quietHours.Checked)
+		prefs.SetString("quiet_hours_start", quietStart.Text)
+		prefs.SetString("quiet_hours_end", quietEnd.Text)
+
+		dialog.ShowInformation(i18n.T("settings.saved_title"), i18n.T("settings.notifications_saved"), w)
+	})
+
+	formContent := container.NewVBox(
+		widget.NewLabelWithStyle(i18n.T("settings.notifications_config"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		enablePolling,
+		i18n.BindLabel("settings.poll_interval"),
+		intervalEntry,
+		widget.NewSeparator(),
+		notifyAssigned,
+		notifyComments,
+		notifyStatus,
+		widget.NewSeparator(),
+		quietHours,
+		container.NewGridWithColumns(2,
+			container.NewVBox(i18n.BindLabel("settings.quiet_hours_start"), quietStart),
+			container.NewVBox(i18n.BindLabel("settings.quiet_hours_end"), quietEnd),
+		),
+	)
+
+	scroll := container.NewVScroll(formContent)
+	scroll.SetMinSize(fyne.NewSize(400, 300))
+
+	pinnedSave := container.NewBorder(nil, saveBtn, nil, nil, scroll)
+
+	return pinnedSave
+}
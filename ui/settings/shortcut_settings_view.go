@@ -0,0 +1,60 @@
+package settings
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/shortcuts"
+)
+
+// BuildShortcutSettings builds the keyboard shortcut settings tab.
+func BuildShortcutSettings(app fyne.App, w fyne.Window) fyne.CanvasObject {
+	prefs := app.Preferences()
+
+	entries := map[string]*widget.Entry{}
+	grid := container.NewGridWithColumns(2)
+	for _, def := range shortcuts.Definitions {
+		entry := widget.NewEntry()
+		entry.SetPlaceHolder(def.DefaultBinding)
+		if binding := shortcuts.Get(prefs, def.ID); binding != def.DefaultBinding {
+			entry.SetText(binding)
+		}
+		entries[def.ID] = entry
+		grid.Add(i18n.BindLabel(def.LabelKey))
+		grid.Add(entry)
+	}
+
+	saveBtn := i18n.BindButton("settings.save", theme.ConfirmIcon(), func() {
+		for _, def := range shortcuts.Definitions {
+			if err := shortcuts.Set(prefs, def.ID, entries[def.ID].Text); err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+		}
+		shortcuts.NotifyChanged()
+		dialog.ShowInformation(i18n.T("settings.saved_title"), i18n.T("settings.shortcuts_saved"), w)
+	})
+
+	resetBtn := i18n.BindButton("settings.shortcuts_reset", theme.ContentUndoIcon(), func() {
+		for _, def := range shortcuts.Definitions {
+			entries[def.ID].SetText("")
+		}
+	})
+
+	formContent := container.NewVBox(
+		widget.NewLabelWithStyle(i18n.T("settings.shortcuts_config"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		i18n.BindLabel("settings.shortcuts_hint"),
+		grid,
+	)
+
+	scroll := container.NewVScroll(formContent)
+	scroll.SetMinSize(fyne.NewSize(400, 300))
+
+	pinnedButtons := container.NewBorder(nil, container.NewVBox(saveBtn, resetBtn), nil, nil, scroll)
+
+	return pinnedButtons
+}
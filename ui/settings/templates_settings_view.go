@@ -0,0 +1,192 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// BuildTemplateSettings builds the issue template manager tab.
+func BuildTemplateSettings(app fyne.App, w fyne.Window) fyne.CanvasObject {
+	prefs := app.Preferences()
+	templates := models.LoadTemplates(prefs)
+	selected := -1
+
+	nameEntry := widget.NewEntry()
+	projectEntry := i18n.BindEntryWithPlaceholder("settings.template_any", false)
+	typeEntry := i18n.BindEntryWithPlaceholder("settings.template_any", false)
+	titleEntry := widget.NewEntry()
+	titleEntry.SetPlaceHolder("[{{component}}] ")
+	descriptionEntry := widget.NewMultiLineEntry()
+	descriptionEntry.Wrapping = fyne.TextWrapWord
+	descriptionEntry.SetMinRowsVisible(8)
+	labelsEntry := i18n.BindEntryWithPlaceholder("bulk.labels_placeholder", false)
+
+	list := widget.NewList(
+		func() int { return len(templates) },
+		func() fyne.CanvasObject { return widget.NewLabel("Template") },
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(templates[i].Name)
+		},
+	)
+
+	fillForm := func(t models.IssueTemplate) {
+		nameEntry.SetText(t.Name)
+		projectEntry.SetText(t.Project)
+		typeEntry.SetText(t.IssueType)
+		titleEntry.SetText(t.TitlePattern)
+		descriptionEntry.SetText(t.Description)
+		labelsEntry.SetText(strings.Join(t.Labels, " "))
+	}
+
+	list.OnSelected = func(id widget.ListItemID) {
+		selected = id
+		fillForm(templates[id])
+	}
+
+	persist := func() bool {
+		if err := models.SaveTemplates(prefs, templates); err != nil {
+			dialog.ShowError(err, w)
+			return false
+		}
+		list.Refresh()
+		return true
+	}
+
+	newBtn := i18n.BindButton("settings.template_new", theme.ContentAddIcon(), func() {
+		selected = -1
+		list.UnselectAll()
+		fillForm(models.IssueTemplate{})
+	})
+
+	saveBtn := i18n.BindButton("settings.save", theme.ConfirmIcon(), func() {
+		name := strings.TrimSpace(nameEntry.Text)
+		if name == "" {
+			dialog.ShowError(errors.New(i18n.T("settings.template_name_missing")), w)
+			return
+		}
+		if i := models.TemplateNameIndex(templates, name); i >= 0 && i != selected {
+			dialog.ShowError(fmt.Errorf(i18n.T("settings.template_name_taken"), templates[i].Name), w)
+			return
+		}
+		t := models.IssueTemplate{
+			Name:         name,
+			Project:      strings.ToUpper(strings.TrimSpace(projectEntry.Text)),
+			IssueType:    strings.TrimSpace(typeEntry.Text),
+			TitlePattern: titleEntry.Text,
+			Description:  descriptionEntry.Text,
+			Labels:       strings.Fields(labelsEntry.Text),
+		}
+		if selected >= 0 && selected < len(templates) {
+			templates[selected] = t
+		} else {
+			templates = append(templates, t)
+			selected = len(templates) - 1
+		}
+		if persist() {
+			list.Select(selected)
+			dialog.ShowInformation(i18n.T("settings.saved_title"), i18n.T("settings.template_saved"), w)
+		}
+	})
+
+	deleteBtn := i18n.BindButton("settings.template_delete", theme.DeleteIcon(), func() {
+		if selected < 0 || selected >= len(templates) {
+			return
+		}
+		dialog.ShowConfirm(i18n.T("settings.template_delete"), templates[selected].Name, func(ok bool) {
+			if !ok {
+				return
+			}
+			templates = append(templates[:selected], templates[selected+1:]...)
+			selected = -1
+			list.UnselectAll()
+			fillForm(models.IssueTemplate{})
+			persist()
+		}, w)
+	})
+	deleteBtn.Importance = widget.DangerImportance
+
+	importBtn := i18n.BindButton("settings.template_import", theme.FolderOpenIcon(), func() {
+		d := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if reader == nil {
+				return
+			}
+			defer reader.Close()
+
+			imported, err := models.ImportTemplates(reader)
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			templates = models.MergeTemplates(templates, imported)
+			if persist() {
+				dialog.ShowInformation(i18n.T("settings.saved_title"), i18n.T("settings.template_imported"), w)
+			}
+		}, w)
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+		d.Show()
+	})
+
+	exportBtn := i18n.BindButton("settings.template_export", theme.DocumentSaveIcon(), func() {
+		d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if writer == nil {
+				return
+			}
+			defer writer.Close()
+
+			if err := models.ExportTemplates(writer, templates); err != nil {
+				dialog.ShowError(err, w)
+			}
+		}, w)
+		d.SetFileName("jirion-templates.json")
+		d.Show()
+	})
+
+	form := container.NewVBox(
+		i18n.BindLabel("settings.template_name"),
+		nameEntry,
+		container.NewGridWithColumns(2,
+			container.NewVBox(i18n.BindLabel("settings.label_project"), projectEntry),
+			container.NewVBox(i18n.BindLabel("backlog.type"), typeEntry),
+		),
+		i18n.BindLabel("settings.template_title"),
+		titleEntry,
+		i18n.BindLabel("settings.template_description"),
+		descriptionEntry,
+		i18n.BindLabel("backlog.labels"),
+		labelsEntry,
+		i18n.BindLabel("settings.template_placeholders"),
+	)
+
+	scroll := container.NewVScroll(form)
+	scroll.SetMinSize(fyne.NewSize(400, 300))
+
+	left := container.NewBorder(nil, container.NewVBox(newBtn, importBtn, exportBtn), nil, nil, list)
+	split := container.NewHSplit(left, scroll)
+	split.Offset = 0.3
+
+	return container.NewBorder(
+		widget.NewLabelWithStyle(i18n.T("settings.template_config"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		container.NewVBox(saveBtn, deleteBtn),
+		nil, nil,
+		split,
+	)
+}
@@ -14,6 +14,9 @@ func SettingsView(app fyne.App, w fyne.Window) fyne.CanvasObject {
 		container.NewTabItem(i18n.T("settings.ai_config"), settings.BuildAISettings(app, w)),
 		container.NewTabItem(i18n.T("settings.label_config"), settings.BuildLabelSettings(app, w)),
 		container.NewTabItem(i18n.T("settings.app_config"), settings.BuildAppSettings(app, w)),
+		container.NewTabItem(i18n.T("settings.shortcuts_config"), settings.BuildShortcutSettings(app, w)),
+		container.NewTabItem(i18n.T("settings.notifications_config"), settings.BuildNotificationSettings(app, w)),
+		container.NewTabItem(i18n.T("settings.template_config"), settings.BuildTemplateSettings(app, w)),
 	)
 	subTabs.SetTabLocation(container.TabLocationTop)
 
@@ -23,6 +26,9 @@ func SettingsView(app fyne.App, w fyne.Window) fyne.CanvasObject {
 			subTabs.Items[1].Text = i18n.T("settings.ai_config")
 			subTabs.Items[2].Text = i18n.T("settings.label_config")
 			subTabs.Items[3].Text = i18n.T("settings.app_config")
+			subTabs.Items[4].Text = i18n.T("settings.shortcuts_config")
+			subTabs.Items[5].Text = i18n.T("settings.notifications_config")
+			subTabs.Items[6].Text = i18n.T("settings.template_config")
 			subTabs.Refresh()
 		})
 	})
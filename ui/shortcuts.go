@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/shortcuts"
+)
+
+// ticketsCommand is sent to the tickets view when a shortcut or palette entry targets it.
+// Key optionally names the issue to open.
+type ticketsCommand struct {
+	Action string
+	Key    string
+}
+
+// registerShortcuts binds all configured shortcuts on the window canvas.
+// Bindings with modifiers are registered as canvas shortcuts, plain keys are
+// handled by the typed-key callback, which only fires while no entry has focus.
+// It returns a function that removes the registered shortcuts again.
+func registerShortcuts(app fyne.App, w fyne.Window, handlers map[string]func()) func() {
+	prefs := app.Preferences()
+	canvas := w.Canvas()
+
+	plainKeys := map[fyne.KeyName]func(){}
+	var registered []fyne.Shortcut
+
+	for _, def := range shortcuts.Definitions {
+		handler, ok := handlers[def.ID]
+		if !ok {
+			continue
+		}
+		binding, err := shortcuts.Parse(shortcuts.Get(prefs, def.ID))
+		if err != nil {
+			fmt.Println("Invalid shortcut:", err)
+			continue
+		}
+
+		if binding.Modifier == 0 {
+			plainKeys[binding.Key] = handler
+			continue
+		}
+		sc := &desktop.CustomShortcut{KeyName: binding.Key, Modifier: binding.Modifier}
+		canvas.AddShortcut(sc, func(fyne.Shortcut) { handler() })
+		registered = append(registered, sc)
+	}
+
+	canvas.SetOnTypedKey(func(ev *fyne.KeyEvent) {
+		if handler, ok := plainKeys[ev.Name]; ok {
+			handler()
+		}
+	})
+
+	return func() {
+		for _, sc := range registered {
+			canvas.RemoveShortcut(sc)
+		}
+		canvas.SetOnTypedKey(nil)
+	}
+}
+
+// showShortcutHelp lists all shortcuts with their current binding.
+func showShortcutHelp(app fyne.App, w fyne.Window) {
+	prefs := app.Preferences()
+	grid := container.NewGridWithColumns(2)
+	for _, def := range shortcuts.Definitions {
+		grid.Add(widget.NewLabel(i18n.T(def.LabelKey)))
+		grid.Add(widget.NewLabelWithStyle(shortcuts.Display(shortcuts.Get(prefs, def.ID)), fyne.TextAlignTrailing, fyne.TextStyle{Monospace: true}))
+	}
+
+	content := container.NewVBox(
+		grid,
+		widget.NewSeparator(),
+		i18n.BindLabel("shortcuts.rebind_hint"),
+	)
+	dialog.ShowCustom(i18n.T("shortcuts.title"), i18n.T("bulk.close"), content, w)
+}
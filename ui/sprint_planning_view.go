@@ -0,0 +1,607 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// sprintDateFormat is the date format of the start sprint dialog.
+const sprintDateFormat = "2006-01-02"
+
+// planningColumn is the backlog (sprint ID 0) or a sprint in the planning view.
+type planningColumn struct {
+	sprint models.JiraSprint
+	issues []models.BoardIssue
+
+	zone   fyne.CanvasObject
+	bg     *canvas.Rectangle
+	cards  []*issueCard
+	totals *widget.Label
+	load   *widget.ProgressBar
+}
+
+// sprintPlanningView shows the backlog and the active and future sprints of a Scrum board
+// side by side. Issues are moved and ranked by drag and drop or with the arrow keys.
+type sprintPlanningView struct {
+	object fyne.CanvasObject
+
+	w                   fyne.Window
+	prefs               fyne.Preferences
+	domain, user, token string
+	openIssue           func(key string)
+
+	boardSelect     *widget.Select
+	defaultCapacity *widget.Entry
+	status          *widget.Label
+	content         *fyne.Container
+
+	boards     map[string]models.JiraBoard
+	board      models.JiraBoard
+	config     models.BoardConfiguration
+	capacities models.SprintCapacities
+	columns    []*planningColumn
+	hovered    *planningColumn
+	focusKey   string
+	loaded     bool
+	request    int
+	// moves are sent to Jira one after the other, so ranks arrive in the shown order
+	moves chan func() error
+}
+
+func newSprintPlanningView(app fyne.App, w fyne.Window, domain, user, token string, openIssue func(key string)) *sprintPlanningView {
+	v := &sprintPlanningView{w: w, prefs: app.Preferences(), domain: domain, user: user, token: token, openIssue: openIssue}
+	v.moves = make(chan func() error, 100)
+	go v.sendMoves()
+	v.status = widget.NewLabel("")
+	v.content = container.NewStack()
+	v.boardSelect = widget.NewSelect([]string{}, v.boardChanged)
+	v.boardSelect.PlaceHolder = i18n.T("agile.no_board")
+
+	v.defaultCapacity = widget.NewEntry()
+	v.defaultCapacity.SetPlaceHolder("0")
+	v.defaultCapacity.OnChanged = v.defaultCapacityChanged
+
+	refreshBtn := widget.NewButtonWithIcon("", theme.ViewRefreshIcon(), v.reload)
+	header := container.NewBorder(nil, nil,
+		container.NewHBox(i18n.BindLabel("agile.board"), v.boardSelect,
+			i18n.BindLabel("planning.default_capacity"), container.NewGridWrap(fyne.NewSize(80, v.defaultCapacity.MinSize().Height), v.defaultCapacity)),
+		refreshBtn, v.status)
+	hint := canvas.NewText(i18n.T("planning.hint"), theme.Color(theme.ColorNamePlaceHolder))
+	hint.TextSize = theme.TextSize() - 2
+	i18n.RegisterOnLanguageChange(func() {
+		fyne.Do(func() {
+			hint.Text = i18n.T("planning.hint")
+			hint.Refresh()
+		})
+	})
+	v.object = container.NewBorder(container.NewVBox(header, hint), nil, nil, nil, v.content)
+	return v
+}
+
+// Load fetches the Scrum boards on first use and selects the last planned one.
+func (v *sprintPlanningView) Load() {
+	if v.loaded {
+		return
+	}
+	v.loaded = true
+	v.status.SetText(i18n.T("board.loading"))
+	go func() {
+		boards, err := models.FetchBoards(v.domain, v.user, v.token, "")
+		fyne.Do(func() {
+			if err != nil {
+				v.loaded = false
+				v.status.SetText(fmt.Sprintf(i18n.T("board.error_load"), err))
+				return
+			}
+			var scrum []models.JiraBoard
+			for _, b := range boards {
+				if b.Type == "scrum" {
+					scrum = append(scrum, b)
+				}
+			}
+			v.status.SetText("")
+			var names []string
+			var selected string
+			v.boards, names, selected = boardOptions(scrum, v.prefs.Int("planning_board"))
+			v.boardSelect.Options = names
+			if len(names) == 0 {
+				v.status.SetText(i18n.T("planning.no_scrum_boards"))
+			}
+			if selected != "" {
+				v.boardSelect.SetSelected(selected)
+			} else {
+				v.boardSelect.Refresh()
+			}
+		})
+	}()
+}
+
+func (v *sprintPlanningView) boardChanged(name string) {
+	board, ok := v.boards[name]
+	if !ok {
+		return
+	}
+	v.board = board
+	v.prefs.SetInt("planning_board", board.ID)
+	v.capacities = models.LoadSprintCapacities(v.prefs, board.ID)
+	// showing the stored value must not save it again
+	v.defaultCapacity.OnChanged = nil
+	v.defaultCapacity.SetText("")
+	if v.capacities.Default > 0 {
+		v.defaultCapacity.SetText(formatEstimate(v.capacities.Default))
+	}
+	v.defaultCapacity.OnChanged = v.defaultCapacityChanged
+	v.reload()
+}
+
+func (v *sprintPlanningView) defaultCapacityChanged(text string) {
+	if v.board.ID == 0 {
+		return
+	}
+	capacity, _ := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	v.capacities.Set(0, capacity)
+	models.SaveSprintCapacities(v.prefs, v.board.ID, v.capacities)
+	v.updateTotals()
+}
+
+// reload fetches estimation field, sprints and the issues of backlog and sprints.
+func (v *sprintPlanningView) reload() {
+	if v.board.ID == 0 {
+		return
+	}
+	v.request++
+	request := v.request
+	board := v.board
+	v.status.SetText(i18n.T("board.loading"))
+	go func() {
+		var columns []*planningColumn
+		config, err := models.FetchBoardConfiguration(v.domain, v.user, v.token, board.ID)
+		var sprints []models.JiraSprint
+		if err == nil {
+			sprints, err = models.FetchSprints(v.domain, v.user, v.token, board.ID, models.SprintActive, models.SprintFuture)
+		}
+		if err == nil {
+			columns = append(columns, &planningColumn{sprint: models.JiraSprint{Name: i18n.T("agile.backlog")}})
+			for _, s := range sprints {
+				columns = append(columns, &planningColumn{sprint: s})
+			}
+			for _, c := range columns {
+				if c.issues, err = models.FetchSprintIssues(v.domain, v.user, v.token, board.ID, c.sprint.ID, config.EstimationField); err != nil {
+					break
+				}
+			}
+		}
+		fyne.Do(func() {
+			if request != v.request {
+				return
+			}
+			if err != nil {
+				v.status.SetText(fmt.Sprintf(i18n.T("board.error_load"), err))
+				return
+			}
+			v.status.SetText("")
+			v.config = config
+			v.columns = columns
+			v.render()
+		})
+	}()
+}
+
+// estimateUnit is the name of the estimation field, or "issues" if the board has none.
+func (v *sprintPlanningView) estimateUnit() string {
+	if v.config.EstimationField == "" {
+		return i18n.T("planning.issues_unit")
+	}
+	return v.config.EstimationName
+}
+
+// total sums the estimates of the column; without estimation field the issues are counted.
+// Sub-tasks are not counted, their estimate is part of the parent's.
+func (v *sprintPlanningView) total(c *planningColumn) float64 {
+	total := 0.0
+	for _, issue := range c.issues {
+		switch {
+		case issue.IssueType.Subtask:
+		case v.config.EstimationField == "":
+			total++
+		default:
+			total += issue.Estimate
+		}
+	}
+	return total
+}
+
+// updateTotals shows each column's total against the capacity of the sprint.
+func (v *sprintPlanningView) updateTotals() {
+	for _, c := range v.columns {
+		total := v.total(c)
+		capacity := 0.0
+		if c.sprint.ID != 0 {
+			capacity = v.capacities.Of(c.sprint.ID)
+		}
+		if capacity <= 0 {
+			c.totals.SetText(fmt.Sprintf(i18n.T("planning.total"), formatEstimate(total), v.estimateUnit()))
+			c.load.Hide()
+			continue
+		}
+		c.totals.SetText(fmt.Sprintf(i18n.T("planning.total_capacity"), formatEstimate(total), formatEstimate(capacity), v.estimateUnit()))
+		if total > capacity {
+			c.totals.Importance = widget.DangerImportance
+		} else {
+			c.totals.Importance = widget.MediumImportance
+		}
+		c.totals.Refresh()
+		c.load.Max = capacity
+		c.load.SetValue(min(total, capacity))
+		c.load.Show()
+	}
+}
+
+func (v *sprintPlanningView) render() {
+	v.hovered = nil
+	grid := container.NewGridWithColumns(max(len(v.columns), 1))
+	var focus *issueCard
+	for ci, c := range v.columns {
+		column := ci
+		c.cards = nil
+		cards := container.NewVBox()
+		for ii, issue := range c.issues {
+			index := ii
+			card := newIssueCard(issue)
+			card.OnDoubleTapped = func() { v.openIssue(card.issue.Key) }
+			card.OnDragged = func(pos fyne.Position) { v.highlight(v.columnAt(pos)) }
+			card.OnDropped = func(pos fyne.Position) { v.dropped(column, index, pos) }
+			card.OnKey = func(key fyne.KeyName) { v.keyPressed(column, index, key) }
+			c.cards = append(c.cards, card)
+			cards.Add(card)
+			if issue.Key == v.focusKey {
+				focus = card
+			}
+		}
+		if len(c.issues) == 0 {
+			cards.Add(widget.NewLabel(i18n.T("planning.empty")))
+		}
+
+		c.bg = canvas.NewRectangle(theme.Color(theme.ColorNameInputBackground))
+		c.bg.CornerRadius = 4
+		c.bg.SetMinSize(fyne.NewSize(boardColumnWidth, 60))
+		c.zone = container.NewStack(c.bg, container.NewVScroll(container.NewPadded(cards)))
+		c.totals = widget.NewLabel("")
+		c.load = widget.NewProgressBar()
+		c.load.TextFormatter = func() string { return "" }
+
+		grid.Add(container.NewBorder(v.columnHeader(c), nil, nil, nil, c.zone))
+	}
+	v.updateTotals()
+	v.content.Objects = []fyne.CanvasObject{container.NewHScroll(grid)}
+	v.content.Refresh()
+
+	if focus != nil {
+		v.w.Canvas().Focus(focus)
+	}
+}
+
+// columnHeader shows name, dates and totals of a sprint with its capacity and actions.
+func (v *sprintPlanningView) columnHeader(c *planningColumn) fyne.CanvasObject {
+	name := widget.NewLabelWithStyle(c.sprint.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	name.Truncation = fyne.TextTruncateEllipsis
+	header := container.NewVBox(name)
+	if c.sprint.ID == 0 {
+		header.Add(widget.NewLabel(fmt.Sprintf(i18n.T("board.issue_count"), len(c.issues))))
+		header.Add(c.totals)
+		return header
+	}
+
+	if dates := sprintDates(c.sprint); dates != "" {
+		header.Add(widget.NewLabel(dates))
+	}
+	capacity := widget.NewEntry()
+	capacity.SetPlaceHolder(formatEstimate(v.capacities.Default))
+	if own, ok := v.capacities.Sprints[c.sprint.ID]; ok {
+		capacity.SetText(formatEstimate(own))
+	}
+	sprintID := c.sprint.ID
+	capacity.OnChanged = func(text string) {
+		if value, err := strconv.ParseFloat(strings.TrimSpace(text), 64); err == nil {
+			v.capacities.Set(sprintID, value)
+		} else {
+			v.capacities.Reset(sprintID)
+		}
+		models.SaveSprintCapacities(v.prefs, v.board.ID, v.capacities)
+		v.updateTotals()
+	}
+	header.Add(container.NewBorder(nil, nil, widget.NewLabel(i18n.T("planning.capacity")), nil, capacity))
+	header.Add(c.totals)
+	header.Add(c.load)
+
+	sprint := c.sprint
+	switch sprint.State {
+	case models.SprintFuture:
+		header.Add(widget.NewButtonWithIcon(i18n.T("planning.start"), theme.MediaPlayIcon(), func() { v.showStartSprint(sprint) }))
+	case models.SprintActive:
+		header.Add(widget.NewButtonWithIcon(i18n.T("planning.complete"), theme.ConfirmIcon(), func() { v.showCompleteSprint(c) }))
+	}
+	return header
+}
+
+// sprintDates formats the start and end date of a sprint, "" if it has none.
+func sprintDates(s models.JiraSprint) string {
+	start, errStart := time.Parse(time.RFC3339, s.StartDate)
+	end, errEnd := time.Parse(time.RFC3339, s.EndDate)
+	if errStart != nil || errEnd != nil {
+		return ""
+	}
+	return start.Local().Format(sprintDateFormat) + " – " + end.Local().Format(sprintDateFormat)
+}
+
+// columnAt returns the column under the absolute position, or nil.
+func (v *sprintPlanningView) columnAt(pos fyne.Position) *planningColumn {
+	driver := fyne.CurrentApp().Driver()
+	for _, c := range v.columns {
+		topLeft := driver.AbsolutePositionForObject(c.zone)
+		size := c.zone.Size()
+		if pos.X >= topLeft.X && pos.X < topLeft.X+size.Width && pos.Y >= topLeft.Y && pos.Y < topLeft.Y+size.Height {
+			return c
+		}
+	}
+	return nil
+}
+
+// highlight marks the column a card is dragged over.
+func (v *sprintPlanningView) highlight(c *planningColumn) {
+	if c == v.hovered {
+		return
+	}
+	if v.hovered != nil {
+		v.hovered.bg.FillColor = theme.Color(theme.ColorNameInputBackground)
+		v.hovered.bg.Refresh()
+	}
+	v.hovered = c
+	if c != nil {
+		c.bg.FillColor = theme.Color(theme.ColorNameHover)
+		c.bg.Refresh()
+	}
+}
+
+// dropped moves the card to the column and position it was dropped at.
+func (v *sprintPlanningView) dropped(from, index int, pos fyne.Position) {
+	v.highlight(nil)
+	target := v.columnAt(pos)
+	if target == nil {
+		return
+	}
+	to := 0
+	for i, c := range v.columns {
+		if c == target {
+			to = i
+		}
+	}
+	// the new position is in front of the first card whose middle is below the pointer
+	driver := fyne.CurrentApp().Driver()
+	position := len(target.cards)
+	for i, card := range target.cards {
+		if driver.AbsolutePositionForObject(card).Y+card.Size().Height/2 > pos.Y {
+			position = i
+			break
+		}
+	}
+	v.move(from, index, to, position)
+}
+
+// keyPressed moves the focused card: left/right to the previous/next sprint, up/down
+// within its column. Return opens the issue.
+func (v *sprintPlanningView) keyPressed(column, index int, key fyne.KeyName) {
+	switch key {
+	case fyne.KeyLeft:
+		if column > 0 {
+			v.move(column, index, column-1, len(v.columns[column-1].issues))
+		}
+	case fyne.KeyRight:
+		if column < len(v.columns)-1 {
+			v.move(column, index, column+1, len(v.columns[column+1].issues))
+		}
+	case fyne.KeyUp:
+		if index > 0 {
+			v.move(column, index, column, index-1)
+		}
+	case fyne.KeyDown:
+		if index < len(v.columns[column].issues)-1 {
+			v.move(column, index, column, index+2)
+		}
+	case fyne.KeyReturn, fyne.KeyEnter:
+		v.openIssue(v.columns[column].issues[index].Key)
+	}
+}
+
+// move puts the issue in front of position in the target column: it is shown there at
+// once, then moved to the sprint (or backlog) and ranked next to its new neighbour.
+func (v *sprintPlanningView) move(from, index, to, position int) {
+	source, target := v.columns[from], v.columns[to]
+	issue := source.issues[index]
+	if from == to && (position == index || position == index+1) {
+		return
+	}
+
+	source.issues = append(source.issues[:index:index], source.issues[index+1:]...)
+	if from == to && position > index {
+		position--
+	}
+	position = min(position, len(target.issues))
+	target.issues = append(target.issues[:position], append([]models.BoardIssue{issue}, target.issues[position:]...)...)
+
+	before, after := "", ""
+	if position+1 < len(target.issues) {
+		before = target.issues[position+1].Key
+	} else if position > 0 {
+		after = target.issues[position-1].Key
+	}
+	v.focusKey = issue.Key
+	v.render()
+
+	sprintID := target.sprint.ID
+	v.moves <- func() error {
+		var err error
+		if from != to {
+			if sprintID == 0 {
+				err = models.MoveIssuesToBacklog(v.domain, v.user, v.token, []string{issue.Key})
+			} else {
+				err = models.MoveIssuesToSprint(v.domain, v.user, v.token, sprintID, []string{issue.Key})
+			}
+		}
+		if err == nil && before != "" {
+			err = models.RankIssues(v.domain, v.user, v.token, []string{issue.Key}, before)
+		} else if err == nil && after != "" {
+			err = models.RankIssuesAfter(v.domain, v.user, v.token, []string{issue.Key}, after)
+		}
+		if err != nil {
+			return fmt.Errorf(i18n.T("planning.move_failed"), issue.Key, err)
+		}
+		return nil
+	}
+}
+
+// sendMoves runs the queued moves in order. After a failure the queued moves are
+// dropped, they were based on the order shown before, and the view is reloaded.
+func (v *sprintPlanningView) sendMoves() {
+	for move := range v.moves {
+		err := move()
+		if err == nil {
+			continue
+		}
+		for len(v.moves) > 0 {
+			<-v.moves
+		}
+		fyne.Do(func() {
+			dialog.ShowError(err, v.w)
+			v.reload()
+		})
+	}
+}
+
+// showStartSprint asks for name, goal and dates and starts the sprint.
+func (v *sprintPlanningView) showStartSprint(sprint models.JiraSprint) {
+	name := widget.NewEntry()
+	name.SetText(sprint.Name)
+	goal := widget.NewMultiLineEntry()
+	goal.SetText(sprint.Goal)
+	goal.SetMinRowsVisible(2)
+
+	start := time.Now()
+	if t, err := time.Parse(time.RFC3339, sprint.StartDate); err == nil {
+		start = t.Local()
+	}
+	startEntry := widget.NewEntry()
+	startEntry.SetText(start.Format(sprintDateFormat))
+	endEntry := widget.NewEntry()
+	if t, err := time.Parse(time.RFC3339, sprint.EndDate); err == nil {
+		endEntry.SetText(t.Local().Format(sprintDateFormat))
+	}
+
+	durations := []string{}
+	for weeks := 1; weeks <= 4; weeks++ {
+		durations = append(durations, fmt.Sprintf(i18n.T("planning.weeks"), weeks))
+	}
+	duration := widget.NewSelect(durations, nil)
+	duration.OnChanged = func(string) {
+		if s, err := time.ParseInLocation(sprintDateFormat, startEntry.Text, time.Local); err == nil {
+			endEntry.SetText(s.AddDate(0, 0, 7*(duration.SelectedIndex()+1)).Format(sprintDateFormat))
+		}
+	}
+	if endEntry.Text == "" {
+		duration.SetSelectedIndex(1)
+	}
+
+	form := widget.NewForm(
+		widget.NewFormItem(i18n.T("planning.sprint_name"), name),
+		widget.NewFormItem(i18n.T("planning.duration"), duration),
+		widget.NewFormItem(i18n.T("planning.start_date"), startEntry),
+		widget.NewFormItem(i18n.T("planning.end_date"), endEntry),
+		widget.NewFormItem(i18n.T("planning.goal"), goal),
+	)
+	d := dialog.NewCustomConfirm(i18n.T("planning.start_title"), i18n.T("planning.start"), i18n.T("bulk.cancel"), form, func(ok bool) {
+		if !ok {
+			return
+		}
+		startDate, errStart := time.ParseInLocation(sprintDateFormat, startEntry.Text, time.Local)
+		endDate, errEnd := time.ParseInLocation(sprintDateFormat, endEntry.Text, time.Local)
+		if errStart != nil || errEnd != nil || !endDate.After(startDate) {
+			dialog.ShowError(errors.New(i18n.T("planning.invalid_dates")), v.w)
+			return
+		}
+		go func() {
+			err := models.StartSprint(v.domain, v.user, v.token, sprint.ID, name.Text, goal.Text, startDate, endDate)
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(err, v.w)
+					return
+				}
+				v.reload()
+			})
+		}()
+	}, v.w)
+	d.Resize(fyne.NewSize(450, 380))
+	d.Show()
+}
+
+// showCompleteSprint closes the sprint after asking where its open issues go.
+func (v *sprintPlanningView) showCompleteSprint(c *planningColumn) {
+	// sub-tasks follow their parent, Jira refuses to move them on their own
+	var open []string
+	done := 0
+	for _, issue := range c.issues {
+		switch {
+		case issue.IssueType.Subtask:
+		case issue.Status.StatusCategory.Key == "done":
+			done++
+		default:
+			open = append(open, issue.Key)
+		}
+	}
+
+	targets := map[string]int{i18n.T("agile.backlog"): 0}
+	options := []string{i18n.T("agile.backlog")}
+	for _, other := range v.columns {
+		if other.sprint.ID != 0 && other.sprint.ID != c.sprint.ID && other.sprint.State == models.SprintFuture {
+			targets[other.sprint.Name] = other.sprint.ID
+			options = append(options, other.sprint.Name)
+		}
+	}
+	moveTo := widget.NewSelect(options, nil)
+	// Jira suggests the next sprint
+	moveTo.SetSelectedIndex(min(1, len(options)-1))
+
+	content := container.NewVBox(widget.NewLabel(fmt.Sprintf(i18n.T("planning.complete_summary"), done, len(open))))
+	if len(open) > 0 {
+		content.Add(widget.NewLabel(i18n.T("planning.move_open_to")))
+		content.Add(moveTo)
+	}
+
+	sprintID := c.sprint.ID
+	dialog.ShowCustomConfirm(fmt.Sprintf(i18n.T("planning.complete_title"), c.sprint.Name), i18n.T("planning.complete"), i18n.T("bulk.cancel"), content, func(ok bool) {
+		if !ok {
+			return
+		}
+		target := targets[moveTo.Selected]
+		go func() {
+			err := models.CompleteSprint(v.domain, v.user, v.token, sprintID, open, target)
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(err, v.w)
+				}
+				v.reload()
+			})
+		}()
+	}, v.w)
+}
@@ -0,0 +1,434 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"image/png"
+	"io"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/software"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+	"github.com/scramb/backlog-manager/ui/components"
+)
+
+// velocitySprintCounts are the numbers of sprints offered for the velocity chart.
+var velocitySprintCounts = []string{"3", "6", "9", "12"}
+
+// Chart colours, taken from the label group palette.
+var (
+	chartRemainingColor = mustHexColor("#0052cc")
+	chartIdealColor     = mustHexColor("#6b778c")
+	chartScopeColor     = mustHexColor("#ffab00")
+	chartCommittedColor = mustHexColor("#6b778c")
+	chartCompletedColor = mustHexColor("#36b37e")
+)
+
+// chartExportSize is the size charts are rendered with for the PNG export.
+var chartExportSize = fyne.NewSize(1200, 600)
+
+func mustHexColor(hex string) color.Color {
+	c, _ := components.HexColor(hex)
+	return c
+}
+
+// sprintReportsView shows the burndown of a sprint and the velocity of the last sprints
+// of a Scrum board.
+type sprintReportsView struct {
+	object fyne.CanvasObject
+
+	w                   fyne.Window
+	prefs               fyne.Preferences
+	domain, user, token string
+
+	boardSelect  *widget.Select
+	sprintSelect *widget.Select
+	unitSelect   *widget.Select
+	countSelect  *widget.Select
+	status       *widget.Label
+	burndownBox  *fyne.Container
+	velocityBox  *fyne.Container
+
+	boards   map[string]models.JiraBoard
+	board    models.JiraBoard
+	config   models.BoardConfiguration
+	sprints  map[string]models.JiraSprint
+	burndown models.SprintReport
+	velocity []models.SprintReport
+	loaded   bool
+	request  int
+	// velocityRequest drops velocity results of a previous board or sprint count
+	velocityRequest int
+}
+
+func newSprintReportsView(app fyne.App, w fyne.Window, domain, user, token string) *sprintReportsView {
+	v := &sprintReportsView{w: w, prefs: app.Preferences(), domain: domain, user: user, token: token}
+	v.status = widget.NewLabel("")
+	v.burndownBox = container.NewStack()
+	v.velocityBox = container.NewStack()
+
+	v.boardSelect = widget.NewSelect([]string{}, v.boardChanged)
+	v.boardSelect.PlaceHolder = i18n.T("agile.no_board")
+	v.sprintSelect = widget.NewSelect([]string{}, func(string) { v.loadBurndown() })
+	v.unitSelect = widget.NewSelect([]string{}, func(string) {
+		v.prefs.SetBool("reports_count_issues", v.countIssues())
+		v.loadBurndown()
+		v.loadVelocity()
+	})
+	v.countSelect = widget.NewSelect(velocitySprintCounts, func(selected string) {
+		v.prefs.SetString("reports_velocity_sprints", selected)
+		v.loadVelocity()
+	})
+	v.countSelect.Selected = v.prefs.StringWithFallback("reports_velocity_sprints", "6")
+
+	burndownPNG := widget.NewButtonWithIcon("PNG", theme.DownloadIcon(), func() {
+		if v.burndown.Sprint.ID == 0 {
+			v.nothingToExport("PNG")
+			return
+		}
+		v.exportPNG(v.burndownChart(v.burndown), fmt.Sprintf("burndown-%s.png", v.burndown.Sprint.Name))
+	})
+	burndownCSV := widget.NewButtonWithIcon("CSV", theme.DownloadIcon(), func() {
+		if v.burndown.Sprint.ID == 0 {
+			v.nothingToExport("CSV")
+			return
+		}
+		v.exportCSV(fmt.Sprintf("burndown-%s.csv", v.burndown.Sprint.Name), func(w io.Writer) error {
+			return models.WriteBurndownCSV(w, v.burndown)
+		})
+	})
+	velocityPNG := widget.NewButtonWithIcon("PNG", theme.DownloadIcon(), func() {
+		if len(v.velocity) == 0 {
+			v.nothingToExport("PNG")
+			return
+		}
+		v.exportPNG(velocityChart(v.velocity), fmt.Sprintf("velocity-%s.png", v.board.Name))
+	})
+	velocityCSV := widget.NewButtonWithIcon("CSV", theme.DownloadIcon(), func() {
+		if len(v.velocity) == 0 {
+			v.nothingToExport("CSV")
+			return
+		}
+		v.exportCSV(fmt.Sprintf("velocity-%s.csv", v.board.Name), func(w io.Writer) error {
+			return models.WriteVelocityCSV(w, v.velocity)
+		})
+	})
+
+	title := func(key string) *widget.Label {
+		l := i18n.BindLabel(key)
+		l.TextStyle = fyne.TextStyle{Bold: true}
+		return l
+	}
+	burndown := container.NewBorder(
+		container.NewBorder(nil, nil, container.NewHBox(title("reports.burndown"), v.sprintSelect), container.NewHBox(burndownPNG, burndownCSV),
+			chartLegend([]string{"reports.remaining", "reports.ideal", "reports.scope"}, []color.Color{chartRemainingColor, chartIdealColor, chartScopeColor})),
+		nil, nil, nil, v.burndownBox)
+	velocity := container.NewBorder(
+		container.NewBorder(nil, nil, container.NewHBox(title("reports.velocity"), i18n.BindLabel("reports.last_sprints"), v.countSelect), container.NewHBox(velocityPNG, velocityCSV),
+			chartLegend([]string{"reports.committed", "reports.completed"}, []color.Color{chartCommittedColor, chartCompletedColor})),
+		nil, nil, nil, v.velocityBox)
+
+	header := container.NewBorder(nil, nil,
+		container.NewHBox(i18n.BindLabel("agile.board"), v.boardSelect, i18n.BindLabel("reports.unit"), v.unitSelect),
+		nil, v.status)
+	v.object = container.NewBorder(header, nil, nil, nil, container.NewGridWithRows(2, burndown, velocity))
+	return v
+}
+
+// chartLegend shows a coloured square per series name.
+func chartLegend(keys []string, colors []color.Color) fyne.CanvasObject {
+	legend := container.NewHBox()
+	for i, key := range keys {
+		swatch := canvas.NewRectangle(colors[i])
+		swatch.SetMinSize(fyne.NewSize(12, 12))
+		legend.Add(container.NewCenter(swatch))
+		legend.Add(i18n.BindLabel(key))
+	}
+	return container.NewCenter(legend)
+}
+
+// Load fetches the Scrum boards on first use and selects the last shown one.
+func (v *sprintReportsView) Load() {
+	if v.loaded {
+		return
+	}
+	v.loaded = true
+	v.status.SetText(i18n.T("board.loading"))
+	go func() {
+		boards, err := models.FetchBoards(v.domain, v.user, v.token, "")
+		fyne.Do(func() {
+			if err != nil {
+				v.loaded = false
+				v.status.SetText(fmt.Sprintf(i18n.T("board.error_load"), err))
+				return
+			}
+			var scrum []models.JiraBoard
+			for _, b := range boards {
+				if b.Type == "scrum" {
+					scrum = append(scrum, b)
+				}
+			}
+			v.status.SetText("")
+			var names []string
+			var selected string
+			v.boards, names, selected = boardOptions(scrum, v.prefs.Int("reports_board"))
+			v.boardSelect.Options = names
+			if len(names) == 0 {
+				v.status.SetText(i18n.T("planning.no_scrum_boards"))
+			}
+			if selected != "" {
+				v.boardSelect.SetSelected(selected)
+			} else {
+				v.boardSelect.Refresh()
+			}
+		})
+	}()
+}
+
+// countIssues reports whether the charts count issues instead of summing estimates.
+func (v *sprintReportsView) countIssues() bool {
+	return v.config.EstimationField == "" || v.unitSelect.SelectedIndex() == 1
+}
+
+func (v *sprintReportsView) boardChanged(name string) {
+	board, ok := v.boards[name]
+	if !ok {
+		return
+	}
+	v.board = board
+	v.prefs.SetInt("reports_board", board.ID)
+	v.request++
+	request := v.request
+	v.status.SetText(i18n.T("board.loading"))
+	go func() {
+		config, err := models.FetchBoardConfiguration(v.domain, v.user, v.token, board.ID)
+		var sprints []models.JiraSprint
+		if err == nil {
+			sprints, err = models.FetchRecentSprints(v.domain, v.user, v.token, board.ID, 12)
+		}
+		var active []models.JiraSprint
+		if err == nil {
+			active, err = models.FetchSprints(v.domain, v.user, v.token, board.ID, models.SprintActive)
+		}
+		fyne.Do(func() {
+			if request != v.request {
+				return
+			}
+			if err != nil {
+				v.status.SetText(fmt.Sprintf(i18n.T("board.error_load"), err))
+				return
+			}
+			v.status.SetText("")
+			v.config = config
+
+			// selecting unit and sprint must not load the charts before both are set
+			onUnitChanged, onSprintChanged := v.unitSelect.OnChanged, v.sprintSelect.OnChanged
+			v.unitSelect.OnChanged, v.sprintSelect.OnChanged = nil, nil
+			v.unitSelect.Options = []string{i18n.T("planning.issues_unit")}
+			v.unitSelect.SetSelectedIndex(0)
+			if config.EstimationField != "" {
+				v.unitSelect.Options = []string{config.EstimationName, i18n.T("planning.issues_unit")}
+				if v.prefs.Bool("reports_count_issues") {
+					v.unitSelect.SetSelectedIndex(1)
+				} else {
+					v.unitSelect.SetSelectedIndex(0)
+				}
+			}
+
+			v.sprints = map[string]models.JiraSprint{}
+			var names []string
+			// newest first: active sprints, then the closed ones
+			all := append([]models.JiraSprint{}, active...)
+			for i := len(sprints) - 1; i >= 0; i-- {
+				all = append(all, sprints[i])
+			}
+			for _, s := range all {
+				name := s.Name
+				if s.State == models.SprintActive {
+					name = fmt.Sprintf(i18n.T("agile.sprint_active"), s.Name)
+				}
+				v.sprints[name] = s
+				names = append(names, name)
+			}
+			v.sprintSelect.Options = names
+			if len(names) > 0 {
+				v.sprintSelect.SetSelectedIndex(0)
+			} else {
+				v.sprintSelect.ClearSelected()
+			}
+			v.unitSelect.OnChanged, v.sprintSelect.OnChanged = onUnitChanged, onSprintChanged
+
+			v.loadBurndown()
+			v.loadVelocity()
+		})
+	}()
+}
+
+// chartMessage replaces the chart with a progress indicator or message.
+func chartMessage(box *fyne.Container, object fyne.CanvasObject) {
+	box.Objects = []fyne.CanvasObject{container.NewCenter(object)}
+	box.Refresh()
+}
+
+func (v *sprintReportsView) loadBurndown() {
+	sprint, ok := v.sprints[v.sprintSelect.Selected]
+	if !ok {
+		chartMessage(v.burndownBox, widget.NewLabel(i18n.T("reports.no_sprints")))
+		return
+	}
+	chartMessage(v.burndownBox, widget.NewProgressBarInfinite())
+	board, estimateField, countIssues := v.board.ID, v.config.EstimationField, v.countIssues()
+	go func() {
+		report, err := models.BuildSprintReport(v.domain, v.user, v.token, board, sprint, estimateField, countIssues)
+		fyne.Do(func() {
+			if v.sprints[v.sprintSelect.Selected].ID != sprint.ID || board != v.board.ID {
+				return
+			}
+			if err != nil {
+				chartMessage(v.burndownBox, widget.NewLabel(fmt.Sprintf(i18n.T("reports.error"), err)))
+				return
+			}
+			v.burndown = report
+			v.burndownBox.Objects = []fyne.CanvasObject{v.burndownChart(report)}
+			v.burndownBox.Refresh()
+		})
+	}()
+}
+
+// burndownChart draws remaining work and scope over the sprint days together with the
+// ideal line from the committed work to zero at the planned end.
+func (v *sprintReportsView) burndownChart(report models.SprintReport) fyne.CanvasObject {
+	unit := v.unitSelect.Selected
+	days := func(t time.Time) float64 { return t.Sub(report.Start).Hours() / 24 }
+	label := func(t time.Time, key string, value float64) string {
+		return fmt.Sprintf("%s – %s: %s %s", t.Local().Format("2006-01-02 15:04"), i18n.T(key), formatEstimate(value), unit)
+	}
+
+	remaining := components.ChartSeries{Name: i18n.T("reports.remaining"), Color: chartRemainingColor, Step: true}
+	scope := components.ChartSeries{Name: i18n.T("reports.scope"), Color: chartScopeColor, Step: true}
+	for _, p := range report.Burndown {
+		remaining.Points = append(remaining.Points, components.ChartPoint{X: days(p.Time), Y: p.Remaining, Label: label(p.Time, "reports.remaining", p.Remaining)})
+		scope.Points = append(scope.Points, components.ChartPoint{X: days(p.Time), Y: p.Scope, Label: label(p.Time, "reports.scope", p.Scope)})
+	}
+	plannedEnd, err := time.Parse(time.RFC3339, report.Sprint.EndDate)
+	if err != nil {
+		plannedEnd = report.End
+	}
+	ideal := components.ChartSeries{Name: i18n.T("reports.ideal"), Color: chartIdealColor, Points: []components.ChartPoint{
+		{X: 0, Y: report.Committed, Label: label(report.Start, "reports.ideal", report.Committed)},
+		{X: days(plannedEnd), Y: 0, Label: label(plannedEnd, "reports.ideal", 0)},
+	}}
+
+	start := report.Start
+	return components.NewLineChart([]components.ChartSeries{remaining, ideal, scope}, func(x float64) string {
+		return start.Add(time.Duration(x * 24 * float64(time.Hour))).Local().Format("2006-01-02")
+	})
+}
+
+func (v *sprintReportsView) loadVelocity() {
+	if v.board.ID == 0 {
+		return
+	}
+	count, _ := strconv.Atoi(v.countSelect.Selected)
+	chartMessage(v.velocityBox, widget.NewProgressBarInfinite())
+	v.velocityRequest++
+	request := v.velocityRequest
+	board, estimateField, countIssues := v.board.ID, v.config.EstimationField, v.countIssues()
+	go func() {
+		var reports []models.SprintReport
+		sprints, err := models.FetchRecentSprints(v.domain, v.user, v.token, board, count)
+		for _, s := range sprints {
+			if err != nil {
+				break
+			}
+			var report models.SprintReport
+			report, err = models.BuildSprintReport(v.domain, v.user, v.token, board, s, estimateField, countIssues)
+			reports = append(reports, report)
+		}
+		fyne.Do(func() {
+			if request != v.velocityRequest {
+				return
+			}
+			if err != nil {
+				chartMessage(v.velocityBox, widget.NewLabel(fmt.Sprintf(i18n.T("reports.error"), err)))
+				return
+			}
+			if len(reports) == 0 {
+				chartMessage(v.velocityBox, widget.NewLabel(i18n.T("reports.no_closed_sprints")))
+				return
+			}
+			v.velocity = reports
+			v.velocityBox.Objects = []fyne.CanvasObject{velocityChart(reports)}
+			v.velocityBox.Refresh()
+		})
+	}()
+}
+
+// velocityChart compares committed and completed work per sprint.
+func velocityChart(reports []models.SprintReport) fyne.CanvasObject {
+	var names []string
+	committed := components.ChartSeries{Name: i18n.T("reports.committed"), Color: chartCommittedColor}
+	completed := components.ChartSeries{Name: i18n.T("reports.completed"), Color: chartCompletedColor}
+	for _, r := range reports {
+		names = append(names, r.Sprint.Name)
+		committed.Values = append(committed.Values, r.Committed)
+		completed.Values = append(completed.Values, r.Completed)
+	}
+	return components.NewBarChart(names, []components.ChartSeries{committed, completed})
+}
+
+// nothingToExport tells that the chart has not been loaded yet.
+func (v *sprintReportsView) nothingToExport(format string) {
+	dialog.ShowInformation(format, i18n.T("reports.nothing_to_export"), v.w)
+}
+
+// exportPNG renders a new instance of the chart offscreen at chartExportSize, so the
+// image does not depend on the window, and saves it as PNG image.
+func (v *sprintReportsView) exportPNG(chart fyne.CanvasObject, name string) {
+	c := software.NewCanvas()
+	c.SetContent(chart)
+	c.Resize(chartExportSize)
+	img := c.Capture()
+
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, v.w)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+		if err := png.Encode(writer, img); err != nil {
+			dialog.ShowError(err, v.w)
+		}
+	}, v.w)
+	d.SetFileName(name)
+	d.Show()
+}
+
+func (v *sprintReportsView) exportCSV(name string, write func(w io.Writer) error) {
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, v.w)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+		if err := write(writer); err != nil {
+			dialog.ShowError(err, v.w)
+		}
+	}, v.w)
+	d.SetFileName(name)
+	d.Show()
+}
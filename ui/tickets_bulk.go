@@ -0,0 +1,321 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// showBulkActionsDialog lets the user pick one action and applies it to all selected issues.
+// onFinished is called after the progress dialog has been closed so the list can be reloaded.
+func showBulkActionsDialog(w fyne.Window, issues []models.JiraIssue, domain, user, token string, onFinished func()) {
+	actions := []string{
+		i18n.T("bulk.action_transition"),
+		i18n.T("bulk.action_add_labels"),
+		i18n.T("bulk.action_remove_labels"),
+		i18n.T("bulk.action_assign"),
+		i18n.T("bulk.action_priority"),
+		i18n.T("bulk.action_comment"),
+	}
+
+	// Transition IDs differ per workflow, so we collect the names available on
+	// the selected issues and resolve the ID per issue when running the action.
+	transitionSelect := widget.NewSelect([]string{}, nil)
+	transitionSelect.PlaceHolder = i18n.T("bulk.loading_transitions")
+	issueTransitions := map[string][]models.JiraTransition{}
+	var transitionsMu sync.Mutex
+	go func() {
+		results := models.RunBulk(issues, func(iss models.JiraIssue) error {
+			ts, err := models.FetchIssueTransitions(domain, user, token, iss.Id)
+			transitionsMu.Lock()
+			issueTransitions[iss.Id] = ts
+			transitionsMu.Unlock()
+			return err
+		}, nil)
+		nameSet := map[string]struct{}{}
+		for _, ts := range issueTransitions {
+			for _, t := range ts {
+				nameSet[t.Name] = struct{}{}
+			}
+		}
+		var names []string
+		for n := range nameSet {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		var failed []models.BulkResult
+		for _, r := range results {
+			if r.Err != nil {
+				failed = append(failed, r)
+			}
+		}
+		fyne.Do(func() {
+			transitionSelect.Options = names
+			transitionSelect.PlaceHolder = i18n.T("bulk.select_transition")
+			if len(failed) > 0 {
+				transitionSelect.PlaceHolder = fmt.Sprintf(i18n.T("bulk.transitions_failed"), len(failed))
+				dialog.ShowError(fmt.Errorf("%s: %w", failed[0].Issue.Key, failed[0].Err), w)
+			}
+			transitionSelect.Refresh()
+		})
+	}()
+
+	labelsEntry := widget.NewEntry()
+	labelsEntry.SetPlaceHolder(i18n.T("bulk.labels_placeholder"))
+
+	priorityMap := map[string]string{}
+	prioritySelect := widget.NewSelect([]string{}, nil)
+	prioritySelect.PlaceHolder = i18n.T("bulk.loading_priorities")
+	go func() {
+		priorities, err := models.FetchPriorities(domain, user, token)
+		if err != nil {
+			fyne.Do(func() { dialog.ShowError(err, w) })
+			return
+		}
+		// the map is read by the apply callback on the UI thread, it is only replaced there
+		loaded := map[string]string{}
+		var names []string
+		for _, p := range priorities {
+			loaded[p.Name] = p.ID
+			names = append(names, p.Name)
+		}
+		fyne.Do(func() {
+			priorityMap = loaded
+			prioritySelect.Options = names
+			prioritySelect.PlaceHolder = i18n.T("bulk.select_priority")
+			prioritySelect.Refresh()
+		})
+	}()
+
+	userMap := map[string]string{}
+	userSelect := widget.NewSelect([]string{}, nil)
+	userSelect.PlaceHolder = i18n.T("bulk.select_user")
+	userSearch := widget.NewEntry()
+	userSearch.SetPlaceHolder(i18n.T("bulk.user_search_placeholder"))
+	userSearch.OnSubmitted = func(query string) {
+		go func() {
+			users, err := models.SearchUsers(domain, user, token, query)
+			if err != nil {
+				fyne.Do(func() { dialog.ShowError(err, w) })
+				return
+			}
+			options := []string{i18n.T("bulk.unassigned")}
+			loaded := map[string]string{i18n.T("bulk.unassigned"): ""}
+			for _, u := range users {
+				name := u.DisplayName
+				if u.Email != "" {
+					name = fmt.Sprintf("%s <%s>", u.DisplayName, u.Email)
+				}
+				loaded[name] = u.AccountID
+				options = append(options, name)
+			}
+			fyne.Do(func() {
+				userMap = loaded
+				userSelect.Options = options
+				userSelect.Refresh()
+			})
+		}()
+	}
+	userBox := container.NewVBox(userSearch, userSelect)
+
+	commentEntry := widget.NewMultiLineEntry()
+	commentEntry.Wrapping = fyne.TextWrapWord
+	commentEntry.SetMinRowsVisible(4)
+
+	inputs := map[string]fyne.CanvasObject{
+		actions[0]: transitionSelect,
+		actions[1]: labelsEntry,
+		actions[2]: labelsEntry,
+		actions[3]: userBox,
+		actions[4]: prioritySelect,
+		actions[5]: commentEntry,
+	}
+
+	inputContainer := container.NewStack()
+	actionSelect := widget.NewSelect(actions, func(selected string) {
+		inputContainer.Objects = []fyne.CanvasObject{inputs[selected]}
+		inputContainer.Refresh()
+	})
+	actionSelect.SetSelected(actions[0])
+
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf(i18n.T("bulk.selected_count"), len(issues))),
+		actionSelect,
+		inputContainer,
+	)
+
+	d := dialog.NewCustomConfirm(i18n.T("bulk.title"), i18n.T("bulk.apply"), i18n.T("bulk.cancel"), content, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		var op func(models.JiraIssue) error
+		switch actionSelect.Selected {
+		case actions[0]:
+			name := transitionSelect.Selected
+			if name == "" {
+				dialog.ShowInformation(i18n.T("tickets.error"), i18n.T("tickets.error_fields"), w)
+				return
+			}
+			op = func(iss models.JiraIssue) error {
+				transitionsMu.Lock()
+				ts := issueTransitions[iss.Id]
+				transitionsMu.Unlock()
+				for _, t := range ts {
+					if t.Name == name {
+						return models.TransitionIssue(domain, user, token, iss.Id, t.ID)
+					}
+				}
+				return fmt.Errorf(i18n.T("bulk.transition_unavailable"), name)
+			}
+		case actions[1], actions[2]:
+			labels := strings.Fields(labelsEntry.Text)
+			if len(labels) == 0 {
+				dialog.ShowInformation(i18n.T("tickets.error"), i18n.T("tickets.error_fields"), w)
+				return
+			}
+			remove := actionSelect.Selected == actions[2]
+			op = func(iss models.JiraIssue) error {
+				if remove {
+					return models.UpdateIssueLabels(domain, user, token, iss.Id, nil, labels)
+				}
+				return models.UpdateIssueLabels(domain, user, token, iss.Id, labels, nil)
+			}
+		case actions[3]:
+			if userSelect.Selected == "" {
+				dialog.ShowInformation(i18n.T("tickets.error"), i18n.T("tickets.error_fields"), w)
+				return
+			}
+			accountID := userMap[userSelect.Selected]
+			op = func(iss models.JiraIssue) error {
+				return models.AssignIssue(domain, user, token, iss.Id, accountID)
+			}
+		case actions[4]:
+			priorityID, found := priorityMap[prioritySelect.Selected]
+			if !found {
+				dialog.ShowInformation(i18n.T("tickets.error"), i18n.T("tickets.error_fields"), w)
+				return
+			}
+			op = func(iss models.JiraIssue) error {
+				return models.SetIssuePriority(domain, user, token, iss.Id, priorityID)
+			}
+		case actions[5]:
+			message := commentEntry.Text
+			if strings.TrimSpace(message) == "" {
+				dialog.ShowInformation(i18n.T("tickets.error"), i18n.T("tickets.error_fields"), w)
+				return
+			}
+			op = func(iss models.JiraIssue) error {
+				return models.AddCommentToTicket(domain, user, token, iss.Id, message)
+			}
+		default:
+			return
+		}
+
+		showBulkProgressDialog(w, actionSelect.Selected, issues, op, onFinished)
+	}, w)
+	d.Resize(fyne.NewSize(480, 360))
+	d.Show()
+}
+
+// showBulkProgressDialog runs op for every issue and lists the per-issue result.
+// Failed issues can be retried from the dialog until everything succeeded.
+func showBulkProgressDialog(w fyne.Window, title string, issues []models.JiraIssue, op func(models.JiraIssue) error, onFinished func()) {
+	var results []models.BulkResult
+	var failed []models.JiraIssue
+
+	progress := widget.NewProgressBar()
+	resultList := widget.NewList(
+		func() int { return len(results) },
+		func() fyne.CanvasObject {
+			return container.NewHBox(widget.NewIcon(nil), widget.NewLabel("KEY"), widget.NewLabel(""))
+		},
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			r := results[i]
+			box := o.(*fyne.Container)
+			icon := box.Objects[0].(*widget.Icon)
+			key := box.Objects[1].(*widget.Label)
+			msg := box.Objects[2].(*widget.Label)
+
+			key.SetText(r.Issue.Key)
+			if r.Err != nil {
+				icon.SetResource(theme.ErrorIcon())
+				msg.SetText(r.Err.Error())
+			} else {
+				icon.SetResource(theme.ConfirmIcon())
+				msg.SetText(i18n.T("bulk.success"))
+			}
+		},
+	)
+
+	summary := widget.NewLabel("")
+	retryBtn := i18n.BindButton("bulk.retry_failed", theme.ViewRefreshIcon(), nil)
+	retryBtn.Disable()
+
+	var run func(batch []models.JiraIssue)
+	run = func(batch []models.JiraIssue) {
+		results = nil
+		progress.Max = float64(len(batch))
+		progress.SetValue(0)
+		retryBtn.Disable()
+		summary.SetText("")
+		resultList.Refresh()
+
+		go func() {
+			var mu sync.Mutex
+			done := 0
+			final := models.RunBulk(batch, op, func(r models.BulkResult) {
+				mu.Lock()
+				done++
+				current := done
+				mu.Unlock()
+				fyne.Do(func() {
+					results = append(results, r)
+					progress.SetValue(float64(current))
+					resultList.Refresh()
+				})
+			})
+
+			fyne.Do(func() {
+				results = final
+				failed = models.FailedIssues(final)
+				summary.SetText(fmt.Sprintf(i18n.T("bulk.summary"), len(final)-len(failed), len(failed)))
+				if len(failed) > 0 {
+					retryBtn.Enable()
+				}
+				resultList.Refresh()
+			})
+		}()
+	}
+
+	retryBtn.OnTapped = func() {
+		run(failed)
+	}
+
+	content := container.NewBorder(
+		container.NewVBox(progress, summary),
+		retryBtn,
+		nil, nil,
+		resultList,
+	)
+
+	d := dialog.NewCustom(title, i18n.T("bulk.close"), content, w)
+	d.SetOnClosed(func() {
+		if onFinished != nil {
+			onFinished()
+		}
+	})
+	d.Resize(fyne.NewSize(520, 400))
+	d.Show()
+
+	run(issues)
+}
@@ -14,50 +14,99 @@ import (
 	"github.com/scramb/backlog-manager/internal/helper"
 	"github.com/scramb/backlog-manager/internal/i18n"
 	"github.com/scramb/backlog-manager/internal/models"
+	"github.com/scramb/backlog-manager/internal/shortcuts"
 	"github.com/scramb/backlog-manager/ui/components"
 )
 
 // NewTicketsView builds the “My Tickets” tab content.
 // It lists all issues assigned to the logged-in user and allows opening them in the browser.
-func TicketsView(app fyne.App, w fyne.Window, domain, user, token string, reloadChan <-chan bool) fyne.CanvasObject {
+func TicketsView(app fyne.App, w fyne.Window, domain, user, token string, reloadChan <-chan bool, commands <-chan ticketsCommand) fyne.CanvasObject {
 	var issues []models.JiraIssue
 	filteredIssues := []models.JiraIssue{}
 	selectedProject := i18n.T("tickets.all_projects")
+	// bulk selection, keyed by issue key so it survives filtering and reloads
+	selectedKeys := map[string]bool{}
+	var updateBulkControls func()
+	var ticketList *widget.List
+	// keyboard cursor for J/K navigation, independent of the list selection which opens an issue
+	cursor := -1
+	refreshList := func() {
+		fyne.Do(func() { ticketList.Refresh() })
+	}
+	// shows problems that only affect how issues are displayed, hidden otherwise
+	statusLabel := widget.NewLabel("")
+	statusLabel.Wrapping = fyne.TextWrapWord
+	statusLabel.Hide()
+	// URLs of icons the list waits for, so binding rows registers only one refresh per icon
+	iconsWaiting := map[string]bool{}
+	listIcon := func(iconURL string) fyne.Resource {
+		var onLoaded func()
+		if !iconsWaiting[iconURL] {
+			iconsWaiting[iconURL] = true
+			onLoaded = func() {
+				fyne.Do(func() {
+					delete(iconsWaiting, iconURL)
+					ticketList.Refresh()
+				})
+			}
+		}
+		return models.CachedIcon(domain, user, token, iconURL, onLoaded)
+	}
 
-	ticketList := widget.NewList(
+	ticketList = widget.NewList(
 		func() int { return len(filteredIssues) },
 		func() fyne.CanvasObject {
+			check := widget.NewCheck("", nil)
 			icon := widget.NewIcon(nil)
 			id := widget.NewLabel("ID")
 			title := widget.NewLabel("Titel")
+			priority := widget.NewIcon(nil)
+			status := components.NewStatusLozenge()
 			openBtn := widget.NewButtonWithIcon("", theme.ViewFullScreenIcon(), nil)
-			box := container.NewHBox(icon, id, title, layout.NewSpacer(), openBtn)
+			box := container.NewHBox(check, icon, id, title, layout.NewSpacer(), priority, container.NewCenter(status), openBtn)
 			return box
 		},
 		func(i widget.ListItemID, o fyne.CanvasObject) {
 			issue := filteredIssues[i]
 			box := o.(*fyne.Container)
 
-			iconWidget := box.Objects[0].(*widget.Icon)
-			idLabel := box.Objects[1].(*widget.Label)
-			titleLabel := box.Objects[2].(*widget.Label)
-			openBtn := box.Objects[4].(*widget.Button)
-
-			var icon fyne.Resource
-			switch issue.Fields.IssueType.Name {
-			case "Epic":
-				icon = theme.GridIcon()
-			case "Story":
-				icon = theme.FileIcon()
-			case "Bug":
-				icon = theme.ErrorIcon()
-			case "Task":
-				icon = theme.DocumentIcon()
-			default:
-				icon = theme.InfoIcon()
+			check := box.Objects[0].(*widget.Check)
+			iconWidget := box.Objects[1].(*widget.Icon)
+			idLabel := box.Objects[2].(*widget.Label)
+			titleLabel := box.Objects[3].(*widget.Label)
+			priorityWidget := box.Objects[5].(*widget.Icon)
+			statusLozenge := box.Objects[6].(*fyne.Container).Objects[0].(*components.StatusLozenge)
+			openBtn := box.Objects[7].(*widget.Button)
+
+			check.OnChanged = nil
+			check.SetChecked(selectedKeys[issue.Key])
+			check.OnChanged = func(checked bool) {
+				if checked {
+					selectedKeys[issue.Key] = true
+				} else {
+					delete(selectedKeys, issue.Key)
+				}
+				updateBulkControls()
 			}
 
+			meta := models.CachedJiraMetadata(domain)
+			issueType := meta.IssueType(issue.Fields.IssueType)
+			priority := meta.Priority(issue.Fields.Priority)
+
+			// icons are loaded lazily, the list is refreshed once they arrive
+			icon := listIcon(issueType.IconURL)
+			if icon == nil {
+				icon = theme.InfoIcon()
+			}
 			iconWidget.SetResource(icon)
+
+			priorityWidget.SetResource(listIcon(priority.IconURL))
+			statusLozenge.SetStatus(meta.Status(issue.Fields.Status))
+			if i == cursor {
+				titleLabel.Importance = widget.HighImportance
+			} else {
+				titleLabel.Importance = widget.MediumImportance
+			}
 			idLabel.SetText(issue.Key)
 			titleLabel.SetText(issue.Fields.Summary)
 
@@ -68,6 +117,66 @@ func TicketsView(app fyne.App, w fyne.Window, domain, user, token string, reload
 		},
 	)
 
+	selectAllCheck := i18n.BindCheckbox("bulk.select_all")
+	bulkBtn := widget.NewButtonWithIcon(i18n.T("bulk.actions"), theme.ListIcon(), nil)
+	bulkBtn.Disable()
+
+	selectedIssues := func() []models.JiraIssue {
+		var out []models.JiraIssue
+		for _, iss := range issues {
+			if selectedKeys[iss.Key] {
+				out = append(out, iss)
+			}
+		}
+		return out
+	}
+
+	updateBulkControls = func() {
+		// drop selections of issues that are no longer loaded
+		loaded := map[string]bool{}
+		for _, iss := range issues {
+			loaded[iss.Key] = true
+		}
+		for key := range selectedKeys {
+			if !loaded[key] {
+				delete(selectedKeys, key)
+			}
+		}
+
+		count := len(selectedKeys)
+		bulkBtn.SetText(fmt.Sprintf("%s (%d)", i18n.T("bulk.actions"), count))
+		if count > 0 {
+			bulkBtn.Enable()
+		} else {
+			bulkBtn.Disable()
+		}
+	}
+
+	selectAllCheck.OnChanged = func(checked bool) {
+		for _, iss := range filteredIssues {
+			if checked {
+				selectedKeys[iss.Key] = true
+			} else {
+				delete(selectedKeys, iss.Key)
+			}
+		}
+		ticketList.Refresh()
+		updateBulkControls()
+	}
+
+	// Issue type icons and status colours come from the site metadata, so
+	// localized and custom issue types render the same way as in Jira.
+	go func() {
+		if _, err := models.LoadJiraMetadata(domain, user, token, false); err != nil {
+			fyne.Do(func() {
+				statusLabel.SetText(fmt.Sprintf(i18n.T("tickets.metadata_failed"), err))
+				statusLabel.Show()
+			})
+			return
+		}
+		refreshList()
+	}()
+
 	searchQuery := ""
 
 	applyFilter := func(project string) {
@@ -86,6 +195,7 @@ func TicketsView(app fyne.App, w fyne.Window, domain, user, token string, reload
 			filteredIssues = append(filteredIssues, iss)
 		}
 		ticketList.Refresh()
+		updateBulkControls()
 	}
 
 	projectFilter := widget.NewSelect([]string{i18n.T("tickets.all_projects")}, func(selected string) {
@@ -131,6 +241,16 @@ func TicketsView(app fyne.App, w fyne.Window, domain, user, token string, reload
 		}()
 	}
 
+	bulkBtn.OnTapped = func() {
+		selected := selectedIssues()
+		if len(selected) == 0 {
+			return
+		}
+		showBulkActionsDialog(w, selected, domain, user, token, func() {
+			reloadBtn.OnTapped()
+		})
+	}
+
 	var contentContainer *fyne.Container
 
 	showListView := func() {
@@ -141,25 +261,107 @@ func TicketsView(app fyne.App, w fyne.Window, domain, user, token string, reload
 					projectFilterLabel,
 					projectFilter,
 					searchEntryWidget,
+					container.NewHBox(selectAllCheck, layout.NewSpacer(), bulkBtn),
 				),
-				nil, nil, nil,
+				statusLabel, nil, nil,
 				ticketList,
 			),
 		}
 		contentContainer.Refresh()
 	}
 
+	// actions of the currently open detail view, nil while the list is shown
+	var detailActions *TicketDetailActions
+
+	openIssue := func(issue models.JiraIssue) {
+		models.AddRecentIssue(app.Preferences(), issue)
+		view, actions := ticketDetailView(app, w, issue, domain, user, token, func() {
+			detailActions = nil
+			showListView()
+		})
+		detailActions = &actions
+		contentContainer.Objects = []fyne.CanvasObject{view}
+		contentContainer.Refresh()
+	}
+
 	ticketList.OnSelected = func(id widget.ListItemID) {
 		if id < 0 || id >= len(filteredIssues) {
 			return
 		}
-		issue := filteredIssues[id]
-		contentContainer.Objects = []fyne.CanvasObject{
-			TicketDetailView(app, w, issue, domain, user, token, showListView),
+		cursor = id
+		// unselect so the same issue can be opened again after going back
+		ticketList.UnselectAll()
+		openIssue(filteredIssues[id])
+	}
+
+	handleCommand := func(cmd ticketsCommand) {
+		switch cmd.Action {
+		case shortcuts.ActionNext, shortcuts.ActionPrevious:
+			if detailActions != nil || len(filteredIssues) == 0 {
+				return
+			}
+			if cmd.Action == shortcuts.ActionNext {
+				cursor++
+			} else {
+				cursor--
+			}
+			if cursor < 0 {
+				cursor = 0
+			}
+			if cursor >= len(filteredIssues) {
+				cursor = len(filteredIssues) - 1
+			}
+			ticketList.ScrollTo(cursor)
+			ticketList.Refresh()
+		case shortcuts.ActionOpen:
+			if cmd.Key != "" {
+				for _, iss := range issues {
+					if iss.Key == cmd.Key {
+						openIssue(iss)
+						return
+					}
+				}
+				// not assigned to me, so load it on its own
+				go func() {
+					issue, err := models.FetchIssue(domain, user, token, cmd.Key)
+					fyne.Do(func() {
+						if err != nil {
+							dialog.ShowError(err, w)
+							return
+						}
+						openIssue(issue)
+					})
+				}()
+				return
+			}
+			if detailActions == nil && cursor >= 0 && cursor < len(filteredIssues) {
+				openIssue(filteredIssues[cursor])
+			}
+		case shortcuts.ActionBack:
+			if detailActions != nil {
+				detailActions = nil
+				showListView()
+			}
+		case shortcuts.ActionComment:
+			if detailActions != nil {
+				detailActions.Comment()
+			}
+		case shortcuts.ActionTransition:
+			if detailActions != nil {
+				detailActions.Transition()
+			}
+		case shortcuts.ActionReload:
+			reloadBtn.OnTapped()
 		}
-		contentContainer.Refresh()
 	}
 
+	go func() {
+		for cmd := range commands {
+			cmd := cmd
+			fyne.Do(func() { handleCommand(cmd) })
+		}
+	}()
+
 	contentContainer = container.NewMax()
 	showListView()
 
@@ -174,6 +376,7 @@ func TicketsView(app fyne.App, w fyne.Window, domain, user, token string, reload
 		fyne.Do(func() {
 			projectFilterLabel.SetText(i18n.T("tickets.project_filter"))
 			reloadBtn.SetText(i18n.T("tickets.reload"))
+			updateBulkControls()
 			searchEntryWidget.SetPlaceHolder(i18n.T("tickets.search_placeholder"))
 
 			prevSelection := projectFilter.Selected
@@ -259,9 +462,49 @@ func TicketsView(app fyne.App, w fyne.Window, domain, user, token string, reload
 	return contentContainer
 }
 
+// TicketDetailActions are the keyboard-triggerable actions of an open detail view.
+type TicketDetailActions struct {
+	Comment    func()
+	Transition func()
+}
+
 // TicketDetailView shows detailed information about a Jira issue with a back button.
 func TicketDetailView(app fyne.App, w fyne.Window, issue models.JiraIssue, domain, user, token string, back func()) fyne.CanvasObject {
+	view, _ := ticketDetailView(app, w, issue, domain, user, token, back)
+	return view
+}
+
+func ticketDetailView(app fyne.App, w fyne.Window, issue models.JiraIssue, domain, user, token string, back func()) (fyne.CanvasObject, TicketDetailActions) {
 	keyLabel := widget.NewLabelWithStyle(issue.Key, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+
+	meta := models.CachedJiraMetadata(domain)
+	issueType := meta.IssueType(issue.Fields.IssueType)
+	priority := meta.Priority(issue.Fields.Priority)
+
+	typeIcon := widget.NewIcon(theme.InfoIcon())
+	priorityIcon := widget.NewIcon(nil)
+	setIcons := func() {
+		if res := models.CachedIcon(domain, user, token, issueType.IconURL, nil); res != nil {
+			typeIcon.SetResource(res)
+		}
+		priorityIcon.SetResource(models.CachedIcon(domain, user, token, priority.IconURL, nil))
+	}
+	models.CachedIcon(domain, user, token, issueType.IconURL, func() { fyne.Do(setIcons) })
+	models.CachedIcon(domain, user, token, priority.IconURL, func() { fyne.Do(setIcons) })
+	setIcons()
+
+	statusLozenge := components.NewStatusLozenge()
+	statusLozenge.SetStatus(meta.Status(issue.Fields.Status))
+	priorityLabel := widget.NewLabel(priority.Name)
+
+	headerRow := container.NewHBox(
+		typeIcon,
+		keyLabel,
+		container.NewCenter(statusLozenge),
+		layout.NewSpacer(),
+		priorityIcon,
+		priorityLabel,
+	)
 	labels, transitions, comments := loadTicketContent(issue, domain, user, token)
 	summaryHeader := i18n.BindLabel("tickets.summary")
 	summaryLabel := widget.NewLabel(issue.Fields.Summary)
@@ -287,6 +530,16 @@ func TicketDetailView(app fyne.App, w fyne.Window, issue models.JiraIssue, domai
 	backBtn := i18n.BindButton("tickets.back", theme.NavigateBackIcon(), func() {
 		back()
 	})
+	addSubtaskBtn := i18n.BindButton("tickets.add_subtask", theme.ContentAddIcon(), func() {
+		showSubtaskWindow(app, issue, domain, user, token)
+	})
+	cloneBtn := i18n.BindButton("clone.button", theme.ContentCopyIcon(), func() {
+		showCloneDialog(app, w, issue, domain, user, token)
+	})
+	if issueType.Subtask || issue.Fields.IssueType.Subtask {
+		// Jira does not allow sub-tasks below sub-tasks
+		addSubtaskBtn.Disable()
+	}
 	transitionOptions := []string{}
 	transitionMap := map[string]string{}
 	for _, t := range transitions {
@@ -313,8 +566,11 @@ func TicketDetailView(app fyne.App, w fyne.Window, issue models.JiraIssue, domai
 	addCommentSection := widget.NewMultiLineEntry()
 	addCommentBtn := i18n.BindButton("tickets.add_comment_button", nil, nil)
 
+	commentAttachments := newAttachmentEditor(app, w, addCommentSection, "ticket-comment")
+
 	commentsContainer.Add(i18n.BindLabel("tickets.add_comment_header"))
 	commentsContainer.Add(addCommentSection)
+	commentsContainer.Add(commentAttachments.object)
 	commentsContainer.Add(addCommentBtn)
 
 	for _, c := range comments {
@@ -322,16 +578,24 @@ func TicketDetailView(app fyne.App, w fyne.Window, issue models.JiraIssue, domai
 	}
 
 	addCommentBtn.OnTapped = func() {
+		text := addCommentSection.Text
+		paths := commentAttachments.Paths()
+		if text == "" {
+			dialog.ShowInformation(i18n.T("tickets.error"), i18n.T("tickets.error_fields"), w)
+			return
+		}
 		addCommentBtn.Disable()
 		go func() {
-			if addCommentSection.Text == "" {
-				fyne.Do(func() {
-					addCommentBtn.Enable()
-					dialog.ShowInformation(i18n.T("tickets.error"), i18n.T("tickets.error_fields"), w)
-				})
-				return
+			var err error
+			if len(paths) > 0 {
+				var media map[string]string
+				media, err = models.UploadInlineImages(domain, user, token, issue.Key, paths)
+				if err == nil {
+					err = models.AddCommentADF(domain, user, token, issue.Key, models.TextWithMediaToADF(text, media))
+				}
+			} else {
+				err = models.AddCommentToTicket(domain, user, token, issue.Id, text)
 			}
-			err := models.AddCommentToTicket(domain, user, token, issue.Id, addCommentSection.Text)
 			fyne.Do(func() {
 				addCommentBtn.Enable()
 				if err != nil {
@@ -340,6 +604,8 @@ func TicketDetailView(app fyne.App, w fyne.Window, issue models.JiraIssue, domai
 				}
 				dialog.ShowInformation(i18n.T("tickets.comment_created"), i18n.T("tickets.created"), w)
 				addCommentSection.SetText("")
+				commentAttachments.SetPaths(nil)
+				removeStoredAttachments(app, paths, models.AttachmentsInUse(app.Preferences(), true))
 			})
 		}()
 	}
@@ -347,8 +613,8 @@ func TicketDetailView(app fyne.App, w fyne.Window, issue models.JiraIssue, domai
 	detailsSection := components.CollapsibleSection(i18n.T("tickets.comment_section_header"), commentsContainer)
 
 	content := container.NewVBox(
-		backBtn,
-		keyLabel,
+		container.NewHBox(backBtn, layout.NewSpacer(), cloneBtn, addSubtaskBtn),
+		headerRow,
 		widget.NewSeparator(),
 		transitionContainer,
 		summaryHeader,
@@ -364,7 +630,59 @@ func TicketDetailView(app fyne.App, w fyne.Window, issue models.JiraIssue, domai
 	scroll := container.NewVScroll(content)
 	scroll.SetMinSize(fyne.NewSize(400, 300))
 
-	return scroll
+	actions := TicketDetailActions{
+		Comment: func() {
+			components.ExpandCollapsibleSection(detailsSection)
+			w.Canvas().Focus(addCommentSection)
+		},
+		Transition: func() {
+			showTransitionDialog(w, issue, transitions, domain, user, token)
+		},
+	}
+
+	return scroll, actions
+}
+
+// showSubtaskWindow opens a create form for a sub-task of the given issue.
+func showSubtaskWindow(app fyne.App, issue models.JiraIssue, domain, user, token string) {
+	win := app.NewWindow(fmt.Sprintf(i18n.T("tickets.add_subtask_title"), issue.Key))
+	win.SetContent(BacklogViewWithPrefill(app, win, domain, user, token, BacklogPrefill{
+		ProjectKey:    models.ProjectKeyOf(issue.Key),
+		ParentKey:     issue.Key,
+		ParentSummary: issue.Fields.Summary,
+		Subtask:       true,
+	}))
+	win.Resize(fyne.NewSize(600, 600))
+	win.Show()
+}
+
+// showTransitionDialog lets the user pick one of the available transitions and applies it.
+func showTransitionDialog(w fyne.Window, issue models.JiraIssue, transitions []models.JiraTransition, domain, user, token string) {
+	var names []string
+	ids := map[string]string{}
+	for _, t := range transitions {
+		names = append(names, t.Name)
+		ids[t.Name] = t.ID
+	}
+	transitionSelect := widget.NewSelect(names, nil)
+	transitionSelect.PlaceHolder = i18n.T("bulk.select_transition")
+
+	dialog.ShowCustomConfirm(i18n.T("tickets.transition_label"), i18n.T("bulk.apply"), i18n.T("bulk.cancel"), transitionSelect, func(ok bool) {
+		if !ok || transitionSelect.Selected == "" {
+			return
+		}
+		id := ids[transitionSelect.Selected]
+		go func() {
+			err := models.TransitionIssue(domain, user, token, issue.Id, id)
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(err, w)
+					return
+				}
+				dialog.ShowInformation(issue.Key, fmt.Sprintf(i18n.T("tickets.transitioned"), transitionSelect.Selected), w)
+			})
+		}()
+	}, w)
 }
 
 func loadTicketContent(issue models.JiraIssue, domain, user, token string) (models.JiraIssueLabels, []models.JiraTransition, []models.JiraComment) {
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"github.com/scramb/backlog-manager/internal/i18n"
+	"github.com/scramb/backlog-manager/internal/models"
+)
+
+// trayIssueCount is the number of assigned issues listed directly in the tray menu.
+const trayIssueCount = 5
+
+// setupSystemTray adds a tray / menu bar icon with the number of open assigned issues,
+// the top issues for one-click access and a quick create window. If enabled in the
+// settings, closing the main window only hides it so Jirion keeps running in the tray.
+func setupSystemTray(app fyne.App, w fyne.Window, domain, user, token string, open func(key string)) {
+	desk, ok := app.(desktop.App)
+	if !ok {
+		return
+	}
+	prefs := app.Preferences()
+
+	var quickCreate fyne.Window
+	showQuickCreate := func() {
+		if quickCreate != nil {
+			quickCreate.RequestFocus()
+			return
+		}
+		quickCreate = app.NewWindow(i18n.T("tray.quick_create"))
+		// no autosave here, the main create form owns the autosaved draft
+		quickCreate.SetContent(BacklogViewWithPrefill(app, quickCreate, domain, user, token, BacklogPrefill{
+			OpenIssue: func(key string) {
+				w.Show()
+				open(key)
+			},
+		}))
+		quickCreate.Resize(fyne.NewSize(600, 500))
+		quickCreate.SetOnClosed(func() { quickCreate = nil })
+		quickCreate.Show()
+	}
+
+	showMain := func() {
+		w.Show()
+		w.RequestFocus()
+	}
+
+	buildMenu := func(issues []models.JiraIssue, loaded bool) *fyne.Menu {
+		status := fyne.NewMenuItem(i18n.T("tray.loading"), nil)
+		if loaded {
+			status.Label = fmt.Sprintf(i18n.T("tray.open_issues"), len(issues))
+		}
+		status.Disabled = true
+
+		items := []*fyne.MenuItem{status}
+		for i, iss := range issues {
+			if i == trayIssueCount {
+				break
+			}
+			key := iss.Key
+			items = append(items, fyne.NewMenuItem(fmt.Sprintf("%s  %s", iss.Key, iss.Fields.Summary), func() {
+				showMain()
+				open(key)
+			}))
+		}
+
+		items = append(items,
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem(i18n.T("tray.quick_create"), showQuickCreate),
+			fyne.NewMenuItem(i18n.T("tray.show"), showMain),
+		)
+		return fyne.NewMenu("Jirion", items...)
+	}
+
+	desk.SetSystemTrayMenu(buildMenu(nil, false))
+
+	// only with the tray menu installed there is a way back to a hidden window; hiding
+	// is opt-in as not every desktop shows tray icons
+	w.SetCloseIntercept(func() {
+		if prefs.Bool("tray_hide_on_close") {
+			w.Hide()
+			return
+		}
+		w.Close()
+	})
+
+	go func() {
+		for {
+			issues, err := models.FetchAssignedIssues(domain, user, token)
+			if err != nil {
+				fmt.Println("Error updating tray:", err)
+			} else {
+				fyne.Do(func() {
+					desk.SetSystemTrayMenu(buildMenu(issues, true))
+				})
+			}
+
+			interval := prefs.IntWithFallback("poll_interval_minutes", 5)
+			if interval < 1 {
+				interval = 1
+			}
+			time.Sleep(time.Duration(interval) * time.Minute)
+		}
+	}()
+}